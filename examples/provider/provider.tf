@@ -1,3 +1,16 @@
 provider "loriot" {
-  # example configuration here
+  region = "eu1"
+}
+
+# Each Loriot instance is configured as a separate provider, selected with
+# the provider meta-argument of resources and data sources.
+provider "loriot" {
+  alias  = "us"
+  region = "us1"
+}
+
+provider "loriot" {
+  alias          = "private"
+  host           = "https://loriot.example.com"
+  ca_bundle_file = "/etc/ssl/private-loriot-ca.pem"
 }
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = AESKeyValidFunction{}
+)
+
+var aesKeyRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{32}$`)
+
+func NewAESKeyValidFunction() function.Function {
+	return AESKeyValidFunction{}
+}
+
+type AESKeyValidFunction struct{}
+
+func (r AESKeyValidFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aes_key_valid"
+}
+
+func (r AESKeyValidFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check an AES-128 key",
+		MarkdownDescription: "Returns whether a value is an AES-128 key in the format accepted by Loriot, 32 hexadecimal characters without separators, such as the `appkey`, `nwkskey` and `appskey` of a device.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "Key to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (r AESKeyValidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &key))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, aesKeyRegexp.MatchString(key)))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAESKeyValidFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "valid" {
+					value = provider::loriot::aes_key_valid("2b7e151628aed2a6abf7158809cf4f3c")
+				}
+
+				output "short" {
+					value = provider::loriot::aes_key_valid("2B7E151628AED2A6")
+				}
+
+				output "separators" {
+					value = provider::loriot::aes_key_valid("2B:7E:15:16:28:AE:D2:A6:AB:F7:15:88:09:CF:4F:3C")
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("valid", "true"),
+					resource.TestCheckOutput("short", "false"),
+					resource.TestCheckOutput("separators", "false"),
+				),
+			},
+		},
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,7 +22,7 @@ func NewAppDataSource() datasource.DataSource {
 
 // AppDataSource defines the data source implementation.
 type AppDataSource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // AppDataSourceModel describes the data source data model.
@@ -128,6 +127,38 @@ func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 						MarkdownDescription: "rwx",
 						Computed:            true,
 					},
+					"duty_cycle": schema.Int64Attribute{
+						MarkdownDescription: "Duty cycle, from 0 (unlimited) to 15 (device disabled)",
+						Computed:            true,
+					},
+					"address": schema.BoolAttribute{
+						MarkdownDescription: "Adaptive data rate (ADR) enabled",
+						Computed:            true,
+					},
+					"address_min": schema.Int64Attribute{
+						MarkdownDescription: "Minimum data rate used when ADR is enabled",
+						Computed:            true,
+					},
+					"address_max": schema.Int64Attribute{
+						MarkdownDescription: "Maximum data rate used when ADR is enabled",
+						Computed:            true,
+					},
+					"address_fix": schema.Int64Attribute{
+						MarkdownDescription: "Fixed data rate used when ADR is enabled",
+						Computed:            true,
+					},
+					"sequence_relax": schema.BoolAttribute{
+						MarkdownDescription: "Relax the uplink sequence number check",
+						Computed:            true,
+					},
+					"sequence_do_not_reset": schema.BoolAttribute{
+						MarkdownDescription: "Do not reset the downlink sequence number when an old uplink sequence number is received",
+						Computed:            true,
+					},
+					"address_count_limit": schema.Int64Attribute{
+						MarkdownDescription: "ADR limit counter for LinkADRReq",
+						Computed:            true,
+					},
 				},
 			},
 		},
@@ -140,12 +171,12 @@ func (d *AppDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *loriot.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -168,9 +199,9 @@ func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	app, _, err := d.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, data.AppId.ValueString())
+	app, httpResp, err := d.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, data.AppId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
 		return
 	}
 
@@ -192,15 +223,15 @@ func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 	if app.CfgDevBase != nil {
 		var configDevBase AppConfigDeviceBaseDataSourceModel
 		configDevBase.DeviceClass = types.StringPointerValue(app.CfgDevBase.Devclass)
-		configDevBase.RxW = types.Int64Value(int64(*app.CfgDevBase.Rxw))
-		configDevBase.DutyCycle = types.Int64Value(int64(*app.CfgDevBase.Dutycycle))
-		configDevBase.Address = types.BoolValue(*app.CfgDevBase.Adr)
-		configDevBase.AddressMin = types.Int64Value(int64(*app.CfgDevBase.AdrMin))
-		configDevBase.AddressMax = types.Int64Value(int64(*app.CfgDevBase.AdrMax))
-		configDevBase.AddressFix = types.Int64Value(int64(*app.CfgDevBase.AdrFix))
-		configDevBase.SequenceRelax = types.BoolValue(*app.CfgDevBase.Seqrelax)
-		configDevBase.SequenceDoNotReset = types.BoolValue(*app.CfgDevBase.Seqdnreset)
-		configDevBase.AddressCountLimit = types.Int64Value(int64(*app.CfgDevBase.AdrCntLimit))
+		configDevBase.RxW = int64PointerValue(app.CfgDevBase.Rxw)
+		configDevBase.DutyCycle = int64PointerValue(app.CfgDevBase.Dutycycle)
+		configDevBase.Address = types.BoolPointerValue(app.CfgDevBase.Adr)
+		configDevBase.AddressMin = int64PointerValue(app.CfgDevBase.AdrMin)
+		configDevBase.AddressMax = int64PointerValue(app.CfgDevBase.AdrMax)
+		configDevBase.AddressFix = int64PointerValue(app.CfgDevBase.AdrFix)
+		configDevBase.SequenceRelax = types.BoolPointerValue(app.CfgDevBase.Seqrelax)
+		configDevBase.SequenceDoNotReset = types.BoolPointerValue(invertBoolPointer(app.CfgDevBase.Seqdnreset))
+		configDevBase.AddressCountLimit = int64PointerValue(app.CfgDevBase.AdrCntLimit)
 		data.ConfigDeviceBase = &configDevBase
 	} else {
 		data.ConfigDeviceBase = nil
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccAppDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_app.test", "id", "BE010000"),
+					resource.TestCheckResourceAttr("data.loriot_app.test", "name", "test"),
+					resource.TestCheckResourceAttr("data.loriot_app.test", "visibility", "private"),
+					resource.TestCheckResourceAttr("data.loriot_app.test", "devices_limit", "10"),
+					resource.TestCheckResourceAttr("data.loriot_app.test", "config_device_base.device_class", "B"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAppDataSourceConfig = `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+
+  config_device_base = {
+    device_class = "B"
+  }
+}
+
+data "loriot_app" "test" {
+  app_id = loriot_app.test.app_id
+}
+`
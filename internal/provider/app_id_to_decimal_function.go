@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = AppIdToDecimalFunction{}
+)
+
+func NewAppIdToDecimalFunction() function.Function {
+	return AppIdToDecimalFunction{}
+}
+
+type AppIdToDecimalFunction struct{}
+
+func (r AppIdToDecimalFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "app_id_to_decimal"
+}
+
+func (r AppIdToDecimalFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an application ID to a decimal number",
+		MarkdownDescription: "Converts an application ID in hexadecimal format, as in the `app_id` attribute of `loriot_app`, to the decimal ID in its `decimal_id` attribute.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "app_id",
+				MarkdownDescription: "Application ID in hexadecimal format",
+			},
+		},
+		Return: function.NumberReturn{},
+	}
+}
+
+func (r AppIdToDecimalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var appId string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &appId))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := parseHexID(appId, 8)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid application ID: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, new(big.Float).SetUint64(value)))
+}
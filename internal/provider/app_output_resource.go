@@ -0,0 +1,835 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppOutputResource{}
+var _ resource.ResourceWithImportState = &AppOutputResource{}
+var _ resource.ResourceWithConfigValidators = &AppOutputResource{}
+
+func NewAppOutputResource() resource.Resource {
+	return &AppOutputResource{}
+}
+
+// AppOutputResource defines the resource implementation.
+type AppOutputResource struct {
+	client *loriotClient
+}
+
+// AppOutputResourceModel describes the resource data model.
+type AppOutputResourceModel struct {
+	AppId       types.String               `tfsdk:"app_id"`
+	OutputId    types.Int64                `tfsdk:"output_id"`
+	Output      types.String               `tfsdk:"output"`
+	HTTPPush    *AppOutputHTTPPushModel    `tfsdk:"http_push"`
+	MQTT        *AppOutputMQTTModel        `tfsdk:"mqtt"`
+	WebSocket   *AppOutputWebSocketModel   `tfsdk:"websocket"`
+	AWSIoT      *AppOutputAWSIoTModel      `tfsdk:"aws_iot"`
+	AzureIoTHub *AppOutputAzureIoTHubModel `tfsdk:"azure_iot_hub"`
+}
+
+type AppOutputHTTPPushModel struct {
+	URL                    types.String `tfsdk:"url"`
+	Authorization          types.String `tfsdk:"authorization"`
+	AuthorizationWO        types.String `tfsdk:"authorization_wo"`
+	AuthorizationWOVersion types.Int64  `tfsdk:"authorization_wo_version"`
+}
+
+type AppOutputMQTTModel struct {
+	Host              types.String `tfsdk:"host"`
+	Port              types.Int64  `tfsdk:"port"`
+	ClientId          types.String `tfsdk:"client_id"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Topic             types.String `tfsdk:"topic"`
+}
+
+type AppOutputWebSocketModel struct {
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+}
+
+type AppOutputAWSIoTModel struct {
+	Region                   types.String `tfsdk:"region"`
+	AccessKeyId              types.String `tfsdk:"access_key_id"`
+	SecretAccessKey          types.String `tfsdk:"secret_access_key"`
+	SecretAccessKeyWO        types.String `tfsdk:"secret_access_key_wo"`
+	SecretAccessKeyWOVersion types.Int64  `tfsdk:"secret_access_key_wo_version"`
+}
+
+type AppOutputAzureIoTHubModel struct {
+	Hostname           types.String `tfsdk:"hostname"`
+	PolicyName         types.String `tfsdk:"policy_name"`
+	PolicyKey          types.String `tfsdk:"policy_key"`
+	PolicyKeyWO        types.String `tfsdk:"policy_key_wo"`
+	PolicyKeyWOVersion types.Int64  `tfsdk:"policy_key_wo_version"`
+}
+
+// Output type names used by the Loriot API for each supported output block.
+const (
+	appOutputHTTPPush    = "httppush"
+	appOutputMQTT        = "mqtt"
+	appOutputWebSocket   = "websocket"
+	appOutputAWSIoT      = "awsiot"
+	appOutputAzureIoTHub = "azureiot"
+)
+
+func (r *AppOutputResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_output"
+}
+
+func (r *AppOutputResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Application data output resource. Exactly one output block must be configured",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the output within the application",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"output": schema.StringAttribute{
+				MarkdownDescription: "Output type name used by the Loriot API",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"http_push": schema.SingleNestedBlock{
+				MarkdownDescription: "HTTP push output, posting messages to a URL. `url` must be set",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "Target URL",
+						Optional:            true,
+					},
+					"authorization": schema.StringAttribute{
+						MarkdownDescription: "Value of the Authorization header sent with each request",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("authorization_wo")),
+						},
+					},
+					"authorization_wo": schema.StringAttribute{
+						MarkdownDescription: "Value of the Authorization header sent with each request, which is not stored in the plan or state. It is sent whenever the output is updated, so `authorization_wo_version` must be changed for a new value to be sent. Requires Terraform 1.11 or later",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("authorization_wo_version")),
+						},
+					},
+					"authorization_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `authorization_wo`, which must be changed to update the Authorization header",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("authorization_wo")),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("url")),
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfOutputTypeChanges(),
+				},
+			},
+			"mqtt": schema.SingleNestedBlock{
+				MarkdownDescription: "MQTT output, publishing messages to a broker. `host`, `port` and `topic` must be set",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Broker hostname",
+						Optional:            true,
+					},
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Broker port",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "MQTT client identifier",
+						Optional:            true,
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "Broker username",
+						Optional:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "Broker password",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
+					},
+					"password_wo": schema.StringAttribute{
+						MarkdownDescription: "Broker password, which is not stored in the plan or state. It is sent whenever the output is updated, so `password_wo_version` must be changed for a new value to be sent. Requires Terraform 1.11 or later",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo_version")),
+						},
+					},
+					"password_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `password_wo`, which must be changed to update the broker password",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
+					},
+					"topic": schema.StringAttribute{
+						MarkdownDescription: "Topic messages are published to",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("host"),
+						path.MatchRelative().AtName("port"),
+						path.MatchRelative().AtName("topic"),
+					),
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfOutputTypeChanges(),
+				},
+			},
+			"websocket": schema.SingleNestedBlock{
+				MarkdownDescription: "WebSocket output, served by the network server",
+				Attributes: map[string]schema.Attribute{
+					"token": schema.StringAttribute{
+						MarkdownDescription: "Application token used to authorize WebSocket clients",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("token_wo")),
+						},
+					},
+					"token_wo": schema.StringAttribute{
+						MarkdownDescription: "Application token used to authorize WebSocket clients, which is not stored in the plan or state. It is sent whenever the output is updated, so `token_wo_version` must be changed for a new value to be sent. Requires Terraform 1.11 or later",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("token_wo_version")),
+						},
+					},
+					"token_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `token_wo`, which must be changed to update the application token",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("token_wo")),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfOutputTypeChanges(),
+				},
+			},
+			"aws_iot": schema.SingleNestedBlock{
+				MarkdownDescription: "AWS IoT output. `region`, `access_key_id` and one of `secret_access_key` or `secret_access_key_wo` must be set",
+				Attributes: map[string]schema.Attribute{
+					"region": schema.StringAttribute{
+						MarkdownDescription: "AWS region",
+						Optional:            true,
+					},
+					"access_key_id": schema.StringAttribute{
+						MarkdownDescription: "AWS access key ID",
+						Optional:            true,
+					},
+					"secret_access_key": schema.StringAttribute{
+						MarkdownDescription: "AWS secret access key",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("secret_access_key_wo")),
+						},
+					},
+					"secret_access_key_wo": schema.StringAttribute{
+						MarkdownDescription: "AWS secret access key, which is not stored in the plan or state. It is sent whenever the output is updated, so `secret_access_key_wo_version` must be changed for a new value to be sent. Requires Terraform 1.11 or later",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_access_key_wo_version")),
+						},
+					},
+					"secret_access_key_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `secret_access_key_wo`, which must be changed to update the AWS secret access key",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_access_key_wo")),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("region"),
+						path.MatchRelative().AtName("access_key_id"),
+					),
+					requiresOneOf("secret_access_key", "secret_access_key_wo"),
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfOutputTypeChanges(),
+				},
+			},
+			"azure_iot_hub": schema.SingleNestedBlock{
+				MarkdownDescription: "Azure IoT Hub output. `hostname`, `policy_name` and one of `policy_key` or `policy_key_wo` must be set",
+				Attributes: map[string]schema.Attribute{
+					"hostname": schema.StringAttribute{
+						MarkdownDescription: "IoT Hub hostname",
+						Optional:            true,
+					},
+					"policy_name": schema.StringAttribute{
+						MarkdownDescription: "Shared access policy name",
+						Optional:            true,
+					},
+					"policy_key": schema.StringAttribute{
+						MarkdownDescription: "Shared access policy key",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("policy_key_wo")),
+						},
+					},
+					"policy_key_wo": schema.StringAttribute{
+						MarkdownDescription: "Shared access policy key, which is not stored in the plan or state. It is sent whenever the output is updated, so `policy_key_wo_version` must be changed for a new value to be sent. Requires Terraform 1.11 or later",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("policy_key_wo_version")),
+						},
+					},
+					"policy_key_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `policy_key_wo`, which must be changed to update the shared access policy key",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("policy_key_wo")),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("hostname"),
+						path.MatchRelative().AtName("policy_name"),
+					),
+					requiresOneOf("policy_key", "policy_key_wo"),
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfOutputTypeChanges(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AppOutputResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("http_push"),
+			path.MatchRoot("mqtt"),
+			path.MatchRoot("websocket"),
+			path.MatchRoot("aws_iot"),
+			path.MatchRoot("azure_iot_hub"),
+		),
+	}
+}
+
+func (r *AppOutputResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppOutputResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config AppOutputResourceModel
+
+	// Read Terraform plan data into the model, and the write-only secrets from
+	// the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	output, osetup := data.outputSetup(config)
+
+	// The add API only accepts the output type, so the output is created first
+	// and then configured through the update API.
+	outputs, httpResp, err := r.client.LoRaApplicationOutputApi.V1NwkAppAPPIDOutputsPost(ctx, loriot.AppidOutputsBody{Output: output}, data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create App Output", httpResp, err))
+		return
+	}
+
+	// The API returns every output of the application, the new one having the
+	// highest identifier.
+	outputId := float64(-1)
+	for _, o := range outputs {
+		if o.Output == output && o.Id > outputId {
+			outputId = o.Id
+		}
+	}
+
+	if outputId < 0 {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find created App Output of type %s", output))
+		return
+	}
+
+	data.OutputId = types.Int64Value(int64(outputId))
+	data.Output = types.StringValue(output)
+
+	body := loriot.OutputsOutputidBody{
+		Output: output,
+		Osetup: &osetup,
+	}
+
+	httpResp, err = r.client.LoRaApplicationOutputApi.V1NwkAppAPPIDOutputsOUTPUTIDPut(ctx, body, data.AppId.ValueString(), outputId)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to configure App Output", httpResp, err))
+
+		// The Output is not saved to state, so remove it rather than leave
+		// it behind to be duplicated by the next apply.
+		httpResp, err = r.client.LoRaApplicationOutputApi.V1NwkAppAPPIDOutputsOUTPUTIDDelete(ctx, data.AppId.ValueString(), outputId)
+		if err != nil && !isNotFound(httpResp, err) {
+			resp.Diagnostics.AddError(clientError("Unable to delete unconfigured App Output", httpResp, err))
+		}
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppOutputResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AppOutputResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Output %d of App %s", data.OutputId.ValueInt64(), data.AppId.ValueString()))
+
+	// Outputs are not available individually, so are looked up in the list
+	// held by the application.
+	app, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, data.AppId.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("App %s no longer exists, removing Output from state", data.AppId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
+		return
+	}
+
+	output, err := findAppOutput(app, data.OutputId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App Output, got error: %s", err))
+		return
+	}
+
+	if output == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Output %d no longer exists in App %s, removing from state", data.OutputId.ValueInt64(), data.AppId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.readOutput(*output)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppOutputResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, data, config AppOutputResourceModel
+
+	// Read Terraform plan data into the model, and the write-only secrets from
+	// the configuration
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The update API replaces the whole setup, so write-only secrets are sent
+	// with every update
+	output, osetup := data.outputSetup(config)
+
+	body := loriot.OutputsOutputidBody{
+		Output: output,
+		Osetup: &osetup,
+	}
+
+	httpResp, err := r.client.LoRaApplicationOutputApi.V1NwkAppAPPIDOutputsOUTPUTIDPut(ctx, body, state.AppId.ValueString(), float64(state.OutputId.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to update App Output", httpResp, err))
+		return
+	}
+
+	data.OutputId = state.OutputId
+	data.Output = types.StringValue(output)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppOutputResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AppOutputResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Output, or its App, may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaApplicationOutputApi.V1NwkAppAPPIDOutputsOUTPUTIDDelete(ctx, data.AppId.ValueString(), float64(data.OutputId.ValueInt64()))
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete App Output", httpResp, err))
+		return
+	}
+}
+
+func (r *AppOutputResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/output_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	outputId, err := strconv.ParseInt(idParts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected output_id to be a number. Got: %q", idParts[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_id"), outputId)...)
+}
+
+// requiresReplaceIfOutputTypeChanges replaces the output when a block is added
+// or removed, as the type of an existing output cannot be changed.
+func requiresReplaceIfOutputTypeChanges() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+		},
+		"The output is replaced when its type changes.",
+		"The output is replaced when its type changes.",
+	)
+}
+
+// findAppOutput returns the output with the given identifier from the
+// application's output list, or nil if it no longer exists.
+func findAppOutput(app loriot.Application, outputId int64) (*loriot.OutputInfo, error) {
+	for _, raw := range app.Outputs {
+		// Outputs are returned untyped, so round-trip them through JSON
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var output loriot.OutputInfo
+		if err := json.Unmarshal(encoded, &output); err != nil {
+			return nil, err
+		}
+
+		if int64(output.Id) == outputId {
+			return &output, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// requiresOneOf returns a validator which checks that a configured block sets
+// at least one of the attributes names, such as a secret or its write-only
+// variant.
+func requiresOneOf(names ...string) validator.Object {
+	return requiresOneOfValidator{names: names}
+}
+
+type requiresOneOfValidator struct {
+	names []string
+}
+
+func (v requiresOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("one of %s must be set", strings.Join(v.names, ", "))
+}
+
+func (v requiresOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requiresOneOfValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	for _, name := range v.names {
+		if value, ok := attributes[name]; ok && (value.IsUnknown() || !value.IsNull()) {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Missing Attribute Configuration",
+		fmt.Sprintf("The %s block is missing a required value, %s.", req.Path, v.Description(ctx)),
+	)
+}
+
+// outputSetup returns the Loriot output type and setup object for the
+// configured output block, taking write-only secrets from config. Secrets
+// fall back to the planned values when config does not have the block.
+func (m *AppOutputResourceModel) outputSetup(config AppOutputResourceModel) (string, interface{}) {
+	switch {
+	case m.HTTPPush != nil:
+		auth := m.HTTPPush.Authorization
+		if config.HTTPPush != nil {
+			auth = writeOnlyValue(auth, config.HTTPPush.AuthorizationWO)
+		}
+		return appOutputHTTPPush, map[string]interface{}{
+			"url":  m.HTTPPush.URL.ValueString(),
+			"auth": auth.ValueString(),
+		}
+	case m.MQTT != nil:
+		password := m.MQTT.Password
+		if config.MQTT != nil {
+			password = writeOnlyValue(password, config.MQTT.PasswordWO)
+		}
+		return appOutputMQTT, map[string]interface{}{
+			"host":     m.MQTT.Host.ValueString(),
+			"port":     m.MQTT.Port.ValueInt64(),
+			"clientid": m.MQTT.ClientId.ValueString(),
+			"username": m.MQTT.Username.ValueString(),
+			"password": password.ValueString(),
+			"topic":    m.MQTT.Topic.ValueString(),
+		}
+	case m.AWSIoT != nil:
+		secret := m.AWSIoT.SecretAccessKey
+		if config.AWSIoT != nil {
+			secret = writeOnlyValue(secret, config.AWSIoT.SecretAccessKeyWO)
+		}
+		return appOutputAWSIoT, map[string]interface{}{
+			"region": m.AWSIoT.Region.ValueString(),
+			"key":    m.AWSIoT.AccessKeyId.ValueString(),
+			"secret": secret.ValueString(),
+		}
+	case m.AzureIoTHub != nil:
+		policyKey := m.AzureIoTHub.PolicyKey
+		if config.AzureIoTHub != nil {
+			policyKey = writeOnlyValue(policyKey, config.AzureIoTHub.PolicyKeyWO)
+		}
+		return appOutputAzureIoTHub, map[string]interface{}{
+			"hostname":   m.AzureIoTHub.Hostname.ValueString(),
+			"policyname": m.AzureIoTHub.PolicyName.ValueString(),
+			"policykey":  policyKey.ValueString(),
+		}
+	default:
+		setup := map[string]interface{}{}
+		if m.WebSocket != nil {
+			token := m.WebSocket.Token
+			if config.WebSocket != nil {
+				token = writeOnlyValue(token, config.WebSocket.TokenWO)
+			}
+			if !token.IsNull() {
+				setup["request"] = map[string]interface{}{
+					"token": token.ValueString(),
+				}
+			}
+		}
+		return appOutputWebSocket, setup
+	}
+}
+
+// readOutput copies the output returned by the API into the model. Secrets
+// are only read back when they are not already known, as the API may mask
+// them, and never when they are set through a write-only attribute.
+func (m *AppOutputResourceModel) readOutput(output loriot.OutputInfo) {
+	m.Output = types.StringValue(output.Output)
+
+	setup := map[string]interface{}{}
+	if output.Osetup != nil {
+		if s, ok := (*output.Osetup).(map[string]interface{}); ok {
+			setup = s
+		}
+	}
+
+	// Clear every block other than the one matching the output type, so a
+	// change of type made outside of Terraform is detected.
+	httpPush, mqtt, webSocket, awsIoT, azureIoTHub := m.HTTPPush, m.MQTT, m.WebSocket, m.AWSIoT, m.AzureIoTHub
+	m.HTTPPush, m.MQTT, m.WebSocket, m.AWSIoT, m.AzureIoTHub = nil, nil, nil, nil, nil
+
+	switch output.Output {
+	case appOutputHTTPPush:
+		if httpPush == nil {
+			httpPush = &AppOutputHTTPPushModel{}
+		}
+		httpPush.URL = osetupString(setup, "url", httpPush.URL)
+		httpPush.Authorization = osetupSecret(setup, "auth", httpPush.Authorization, httpPush.AuthorizationWOVersion)
+		m.HTTPPush = httpPush
+	case appOutputMQTT:
+		if mqtt == nil {
+			mqtt = &AppOutputMQTTModel{}
+		}
+		mqtt.Host = osetupString(setup, "host", mqtt.Host)
+		mqtt.Port = osetupInt64(setup, "port", mqtt.Port)
+		mqtt.ClientId = osetupString(setup, "clientid", mqtt.ClientId)
+		mqtt.Username = osetupString(setup, "username", mqtt.Username)
+		mqtt.Password = osetupSecret(setup, "password", mqtt.Password, mqtt.PasswordWOVersion)
+		mqtt.Topic = osetupString(setup, "topic", mqtt.Topic)
+		m.MQTT = mqtt
+	case appOutputWebSocket:
+		if webSocket == nil {
+			webSocket = &AppOutputWebSocketModel{}
+		}
+		if request, ok := setup["request"].(map[string]interface{}); ok {
+			webSocket.Token = osetupSecret(request, "token", webSocket.Token, webSocket.TokenWOVersion)
+		}
+		m.WebSocket = webSocket
+	case appOutputAWSIoT:
+		if awsIoT == nil {
+			awsIoT = &AppOutputAWSIoTModel{}
+		}
+		awsIoT.Region = osetupString(setup, "region", awsIoT.Region)
+		awsIoT.AccessKeyId = osetupString(setup, "key", awsIoT.AccessKeyId)
+		awsIoT.SecretAccessKey = osetupSecret(setup, "secret", awsIoT.SecretAccessKey, awsIoT.SecretAccessKeyWOVersion)
+		m.AWSIoT = awsIoT
+	case appOutputAzureIoTHub:
+		if azureIoTHub == nil {
+			azureIoTHub = &AppOutputAzureIoTHubModel{}
+		}
+		azureIoTHub.Hostname = osetupString(setup, "hostname", azureIoTHub.Hostname)
+		azureIoTHub.PolicyName = osetupString(setup, "policyname", azureIoTHub.PolicyName)
+		azureIoTHub.PolicyKey = osetupSecret(setup, "policykey", azureIoTHub.PolicyKey, azureIoTHub.PolicyKeyWOVersion)
+		m.AzureIoTHub = azureIoTHub
+	}
+}
+
+// osetupString returns the string setting with the given key, or current if
+// the setting is not present or empty and current is not configured.
+func osetupString(setup map[string]interface{}, key string, current types.String) types.String {
+	value, ok := setup[key].(string)
+	if !ok || (value == "" && current.IsNull()) {
+		return current
+	}
+
+	return types.StringValue(value)
+}
+
+// osetupSecret returns the secret setting with the given key only if current
+// is not already known and writeOnlyVersion is null, as secrets set through a
+// write-only attribute must not be stored in state.
+func osetupSecret(setup map[string]interface{}, key string, current types.String, writeOnlyVersion types.Int64) types.String {
+	if (!current.IsNull() && !current.IsUnknown()) || !writeOnlyVersion.IsNull() {
+		return current
+	}
+
+	return osetupString(setup, key, current)
+}
+
+// osetupInt64 returns the numeric setting with the given key, or current if
+// the setting is not present or zero and current is not configured.
+func osetupInt64(setup map[string]interface{}, key string, current types.Int64) types.Int64 {
+	var value int64
+
+	switch v := setup[key].(type) {
+	case float64:
+		value = int64(v)
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return current
+		}
+		value = parsed
+	default:
+		return current
+	}
+
+	if value == 0 && current.IsNull() {
+		return current
+	}
+
+	return types.Int64Value(value)
+}
@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAppOutputResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccAppOutputResourceConfig("https://example.com/one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app_output.test", "output_id", "0"),
+					resource.TestCheckResourceAttr("loriot_app_output.test", "output", "httppush"),
+					resource.TestCheckResourceAttr("loriot_app_output.test", "http_push.url", "https://example.com/one"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_app_output.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_app_output.test", "app_id", "output_id"),
+				ImportStateVerifyIdentifierAttribute: "output_id",
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccAppOutputResourceConfig("https://example.com/two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app_output.test", "output_id", "0"),
+					resource.TestCheckResourceAttr("loriot_app_output.test", "http_push.url", "https://example.com/two"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAppOutputResource_configureError(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Output rejected by the update API
+			{
+				Config:      server.providerConfig() + testAccAppOutputResourceConfig("ftp://example.com/one"),
+				ExpectError: regexp.MustCompile(`Unable to configure App Output`),
+			},
+			// Create and Read testing, without a leftover Output
+			{
+				Config: server.providerConfig() + testAccAppOutputResourceConfig("https://example.com/one"),
+				Check: func(s *terraform.State) error {
+					app, _, err := server.client().LoRaApplicationApi.V1NwkAppAPPIDGet(context.Background(), "BE010000")
+					if err != nil {
+						return err
+					}
+
+					if len(app.Outputs) != 1 {
+						return fmt.Errorf("expected 1 output, got %d", len(app.Outputs))
+					}
+
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAppOutputResource_missingAttributes(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	testCases := map[string]struct {
+		block       string
+		expectError string
+	}{
+		"http_push": {
+			block:       `http_push {}`,
+			expectError: `Invalid Attribute Combination`,
+		},
+		"mqtt": {
+			block:       `mqtt {}`,
+			expectError: `Invalid Attribute Combination`,
+		},
+		"aws_iot": {
+			block:       `aws_iot {}`,
+			expectError: `Invalid Attribute Combination`,
+		},
+		"aws_iot-secret": {
+			block: `aws_iot {
+    region        = "eu-west-1"
+    access_key_id = "AKIAEXAMPLE"
+  }`,
+			expectError: `Missing Attribute Configuration`,
+		},
+		"azure_iot_hub": {
+			block:       `azure_iot_hub {}`,
+			expectError: `Invalid Attribute Combination`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: server.providerConfig() + fmt.Sprintf(`
+resource "loriot_app_output" "test" {
+  app_id = "BE010000"
+
+  %[1]s
+}
+`, testCase.block),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(testCase.expectError),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccAppOutputResource_writeOnly(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	// testCheckPassword checks the password the output was configured with,
+	// as it is not stored in state.
+	testCheckPassword := func(password string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			app, _, err := server.client().LoRaApplicationApi.V1NwkAppAPPIDGet(context.Background(), "BE010000")
+			if err != nil {
+				return err
+			}
+
+			output, err := findAppOutput(app, 0)
+			if err != nil {
+				return err
+			}
+
+			if output == nil || output.Osetup == nil {
+				return fmt.Errorf("expected output 0 to be configured")
+			}
+
+			if setup, _ := (*output.Osetup).(map[string]interface{}); setup["password"] != password {
+				return fmt.Errorf("expected output password %q, got %v", password, setup["password"])
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccAppOutputResourceWriteOnlyConfig("one", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_app_output.test", "mqtt.password"),
+					resource.TestCheckNoResourceAttr("loriot_app_output.test", "mqtt.password_wo"),
+					resource.TestCheckResourceAttr("loriot_app_output.test", "mqtt.password_wo_version", "1"),
+					testCheckPassword("one"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccAppOutputResourceWriteOnlyConfig("two", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_app_output.test", "mqtt.password"),
+					resource.TestCheckResourceAttr("loriot_app_output.test", "mqtt.password_wo_version", "2"),
+					testCheckPassword("two"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAppOutputResourceConfig(url string) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_app_output" "test" {
+  app_id = loriot_app.test.app_id
+
+  http_push {
+    url           = %[1]q
+    authorization = "Bearer secret"
+  }
+}
+`, url)
+}
+
+func testAccAppOutputResourceWriteOnlyConfig(password string, passwordVersion int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_app_output" "test" {
+  app_id = loriot_app.test.app_id
+
+  mqtt {
+    host                = "mqtt.example.com"
+    port                = 8883
+    username            = "loriot"
+    password_wo         = %[1]q
+    password_wo_version = %[2]d
+    topic               = "uplinks"
+  }
+}
+`, password, passwordVersion)
+}
+
+func TestAppOutputSetupWithoutConfigBlock(t *testing.T) {
+	testCases := map[string]struct {
+		model AppOutputResourceModel
+		key   string
+		want  interface{}
+	}{
+		"http_push": {
+			model: AppOutputResourceModel{HTTPPush: &AppOutputHTTPPushModel{URL: types.StringValue("https://example.com"), Authorization: types.StringValue("secret")}},
+			key:   "auth",
+			want:  "secret",
+		},
+		"mqtt": {
+			model: AppOutputResourceModel{MQTT: &AppOutputMQTTModel{Password: types.StringValue("secret")}},
+			key:   "password",
+			want:  "secret",
+		},
+		"aws_iot": {
+			model: AppOutputResourceModel{AWSIoT: &AppOutputAWSIoTModel{SecretAccessKey: types.StringValue("secret")}},
+			key:   "secret",
+			want:  "secret",
+		},
+		"azure_iot_hub": {
+			model: AppOutputResourceModel{AzureIoTHub: &AppOutputAzureIoTHubModel{PolicyKey: types.StringValue("secret")}},
+			key:   "policykey",
+			want:  "secret",
+		},
+		"websocket": {
+			model: AppOutputResourceModel{WebSocket: &AppOutputWebSocketModel{Token: types.StringValue("secret")}},
+			key:   "request",
+			want:  map[string]interface{}{"token": "secret"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, osetup := testCase.model.outputSetup(AppOutputResourceModel{})
+
+			setup, ok := osetup.(map[string]interface{})
+			if !ok {
+				t.Fatalf("expected setup map, got %T", osetup)
+			}
+
+			if got := setup[testCase.key]; !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("setup[%q] = %v, want %v", testCase.key, got, testCase.want)
+			}
+		})
+	}
+}
@@ -7,13 +7,27 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
+	"net/url"
 
 	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -27,22 +41,69 @@ func NewAppResource() resource.Resource {
 
 // AppResource defines the resource implementation.
 type AppResource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // AppResourceModel describes the resource data model.
 type AppResourceModel struct {
-	AppId          types.String  `tfsdk:"app_id"`
-	DecimalId      types.Float64 `tfsdk:"decimal_id"`
-	Name           types.String  `tfsdk:"name"`
-	OwnerId        types.Float64 `tfsdk:"owner_id"`
-	OrganizationId types.Float64 `tfsdk:"organization_id"`
-	//visibility
+	AppId             types.String  `tfsdk:"app_id"`
+	DecimalId         types.Float64 `tfsdk:"decimal_id"`
+	Name              types.String  `tfsdk:"name"`
+	OwnerId           types.Float64 `tfsdk:"owner_id"`
+	OrganizationId    types.Float64 `tfsdk:"organization_id"`
+	Visibility        types.String  `tfsdk:"visibility"`
 	CreatedDate       types.String  `tfsdk:"created_date"`
 	DevicesUsed       types.Float64 `tfsdk:"devices_used"`
 	DevicesLimit      types.Float64 `tfsdk:"devices_limit"`
 	MCastDevicesUsed  types.Float64 `tfsdk:"mcast_devices_used"`
 	MCastDevicesLimit types.Float64 `tfsdk:"mcast_devices_limit"`
+	ConfigDeviceBase  types.Object  `tfsdk:"config_device_base"`
+}
+
+// AppConfigDeviceBaseResourceModel describes the base configuration applied to
+// devices registered with the application.
+type AppConfigDeviceBaseResourceModel struct {
+	DeviceClass        types.String `tfsdk:"device_class"`
+	RxW                types.Int64  `tfsdk:"rxw"`
+	DutyCycle          types.Int64  `tfsdk:"duty_cycle"`
+	Address            types.Bool   `tfsdk:"address"`
+	AddressMin         types.Int64  `tfsdk:"address_min"`
+	AddressMax         types.Int64  `tfsdk:"address_max"`
+	AddressFix         types.Int64  `tfsdk:"address_fix"`
+	SequenceRelax      types.Bool   `tfsdk:"sequence_relax"`
+	SequenceDoNotReset types.Bool   `tfsdk:"sequence_do_not_reset"`
+	AddressCountLimit  types.Int64  `tfsdk:"address_count_limit"`
+}
+
+// appCreateBody extends the client's application registration body with the
+// organization, which the generated client does not model.
+type appCreateBody struct {
+	loriot.NwkAppsBody
+	OrganizationId float64 `json:"organizationId,omitempty"`
+}
+
+// appMcastDevLimitBody is the body of the multicast device limit API, which
+// the generated client does not model.
+type appMcastDevLimitBody struct {
+	Mcastdevlimit float64 `json:"mcastdevlimit"`
+}
+
+// appVisibilityValues are the visibilities an application can have.
+var appVisibilityValues = []string{"private", "public"}
+
+func (m AppConfigDeviceBaseResourceModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"device_class":          types.StringType,
+		"rxw":                   types.Int64Type,
+		"duty_cycle":            types.Int64Type,
+		"address":               types.BoolType,
+		"address_min":           types.Int64Type,
+		"address_max":           types.Int64Type,
+		"address_fix":           types.Int64Type,
+		"sequence_relax":        types.BoolType,
+		"sequence_do_not_reset": types.BoolType,
+		"address_count_limit":   types.Int64Type,
+	}
 }
 
 func (r *AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,10 +141,26 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed:            true,
 			},
 			"organization_id": schema.Float64Attribute{
-				MarkdownDescription: "Identifier of the organization the application belongs to",
+				MarkdownDescription: "Identifier of the organization the application belongs to. Defaults to the organization of the API key. Changing this forces a new application to be created",
 				Required:            false,
-				Optional:            false,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Visibility of the application to other members of the organization, one of `private` or `public`. Defaults to `private`",
+				Required:            false,
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(appVisibilityValues...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_date": schema.StringAttribute{
 				MarkdownDescription: "Creation date",
@@ -96,24 +173,138 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Required:            false,
 				Optional:            false,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"devices_limit": schema.Float64Attribute{
-				MarkdownDescription: "Limit of devices which can be registered",
+				MarkdownDescription: "Limit of devices which can be registered. Capacity is allocated in blocks of 10 devices, so other values are rounded up to the next multiple of 10 when allocating it",
 				Required:            true,
 				Optional:            false,
 				Computed:            false,
+				Validators: []validator.Float64{
+					capacityValidator{step: appCapacityStep},
+				},
 			},
 			"mcast_devices_used": schema.Float64Attribute{
 				MarkdownDescription: "Number of multicast devices registered with the application",
 				Required:            false,
 				Optional:            false,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"mcast_devices_limit": schema.Float64Attribute{
-				MarkdownDescription: "Limit of multicate devices which can be registered",
+				MarkdownDescription: "Limit of multicast devices which can be registered",
 				Required:            true,
 				Optional:            false,
 				Computed:            false,
+				Validators: []validator.Float64{
+					capacityValidator{step: 1},
+				},
+			},
+			"config_device_base": schema.SingleNestedAttribute{
+				MarkdownDescription: "Base configuration applied to devices registered with the application",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"device_class": schema.StringAttribute{
+						MarkdownDescription: "Device LoRaWAN class type, one of `A`, `B` or `C`",
+						Optional:            true,
+						Computed:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("A", "B", "C"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"rxw": schema.Int64Attribute{
+						MarkdownDescription: "Receive window used after an uplink, coded as automatic (0), RX1 (1) or RX2 (2)",
+						Optional:            true,
+						Computed:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 2),
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"duty_cycle": schema.Int64Attribute{
+						MarkdownDescription: "Duty cycle, from 0 (unlimited) to 15 (device disabled)",
+						Optional:            true,
+						Computed:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 15),
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"address": schema.BoolAttribute{
+						MarkdownDescription: "Enable adaptive data rate (ADR)",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"address_min": schema.Int64Attribute{
+						MarkdownDescription: "Minimum data rate used when ADR is enabled",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"address_max": schema.Int64Attribute{
+						MarkdownDescription: "Maximum data rate used when ADR is enabled",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"address_fix": schema.Int64Attribute{
+						MarkdownDescription: "Fixed data rate used when ADR is enabled",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"sequence_relax": schema.BoolAttribute{
+						MarkdownDescription: "Relax the uplink sequence number check",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"sequence_do_not_reset": schema.BoolAttribute{
+						MarkdownDescription: "Do not reset the downlink sequence number when an old uplink sequence number is received",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"address_count_limit": schema.Int64Attribute{
+						MarkdownDescription: "ADR limit counter for LinkADRReq, between 1 and 255",
+						Optional:            true,
+						Computed:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 255),
+						},
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+				},
 			},
 		},
 	}
@@ -125,12 +316,12 @@ func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *loriot.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -149,30 +340,61 @@ func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	body := loriot.NwkAppsBody{
-		Title: data.Name.ValueString(),
-		// Value must be a multiple of 10. In this case, round up.
-		Capacity:      math.Round(data.DevicesLimit.ValueFloat64()/10) * 10,
-		Visibility:    "private",
-		Mcastdevlimit: data.MCastDevicesLimit.ValueFloat64(),
+	visibility := "private"
+	if !data.Visibility.IsUnknown() && !data.Visibility.IsNull() {
+		visibility = data.Visibility.ValueString()
+	}
+
+	body := appCreateBody{
+		NwkAppsBody: loriot.NwkAppsBody{
+			Title:         data.Name.ValueString(),
+			Capacity:      allocatedCapacity(data.DevicesLimit.ValueFloat64()),
+			Visibility:    visibility,
+			Mcastdevlimit: data.MCastDevicesLimit.ValueFloat64(),
+		},
+	}
+
+	if !data.OrganizationId.IsUnknown() && !data.OrganizationId.IsNull() {
+		body.OrganizationId = data.OrganizationId.ValueFloat64()
 	}
 
 	opts := loriot.LoRaApplicationApi1NwkAppsPostOpts{
 		Body: optional.NewInterface(body),
 	}
 
-	app, _, err := r.client.LoRaApplicationApi.V1NwkAppsPost(ctx, &opts)
+	app, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppsPost(ctx, &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create App", httpResp, err))
+		return
+	}
+
+	// The device base configuration is not accepted when creating the
+	// application, so is applied afterwards.
+	if !data.ConfigDeviceBase.IsNull() && !data.ConfigDeviceBase.IsUnknown() {
+		resp.Diagnostics.Append(r.updateConfigDeviceBase(ctx, app.AppHexId, data.ConfigDeviceBase)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	details, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, app.AppHexId)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create App, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
 		return
 	}
 
+	configDeviceBase, diags := appConfigDeviceBaseValue(details.CfgDevBase)
+	resp.Diagnostics.Append(diags...)
+	data.ConfigDeviceBase = configDeviceBase
+
 	data.AppId = types.StringValue(app.AppHexId)
 	data.OrganizationId = types.Float64Value(app.OrganizationId)
+	data.Visibility = types.StringValue(details.Visibility)
 	data.OwnerId = types.Float64Value(app.Ownerid)
 	data.DecimalId = types.Float64Value(app.Id)
 	data.CreatedDate = types.StringValue(app.Created)
-	data.DevicesLimit = types.Float64Value(app.DeviceLimit)
+	data.DevicesLimit = devicesLimitValue(data.DevicesLimit, app.DeviceLimit)
 	data.DevicesUsed = types.Float64Value(app.Devices)
 	data.MCastDevicesLimit = types.Float64Value(app.Mcastdevlimit)
 	data.MCastDevicesUsed = types.Float64Value(app.Mcastdevices)
@@ -200,9 +422,15 @@ func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 
-	app, _, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, data.AppId.ValueString())
+	app, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, data.AppId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("App %s no longer exists, removing from state", data.AppId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
 		return
 	}
 
@@ -211,12 +439,15 @@ func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	data.Name = types.StringValue(app.Name)
 	data.OwnerId = types.Float64Value(app.Ownerid)
 	data.OrganizationId = types.Float64Value(app.OrganizationId)
-	//visibility
+	data.Visibility = types.StringValue(app.Visibility)
 	data.CreatedDate = types.StringValue(app.Created)
 	data.DevicesUsed = types.Float64Value(app.Devices)
-	data.DevicesLimit = types.Float64Value(app.DeviceLimit)
+	data.DevicesLimit = devicesLimitValue(data.DevicesLimit, app.DeviceLimit)
 	data.MCastDevicesUsed = types.Float64Value(app.Mcastdevices)
 	data.MCastDevicesLimit = types.Float64Value(app.Mcastdevlimit)
+	configDeviceBase, diags := appConfigDeviceBaseValue(app.CfgDevBase)
+	resp.Diagnostics.Append(diags...)
+	data.ConfigDeviceBase = configDeviceBase
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -240,44 +471,69 @@ func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			Title: data.Name.ValueString(),
 		}
 
-		_, _, nameErr := r.client.LoRaApplicationApi.V1NwkAppAPPIDTitlePost(ctx, titleBody, state.AppId.ValueString())
+		_, httpResp, nameErr := r.client.LoRaApplicationApi.V1NwkAppAPPIDTitlePost(ctx, titleBody, state.AppId.ValueString())
 
 		if nameErr != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update name of App, got error: %s", nameErr))
+			resp.Diagnostics.AddError(clientError("Unable to update name of App", httpResp, nameErr))
 			return
 		}
 	}
 
-	if !data.DevicesLimit.Equal(state.DevicesLimit) {
+	// Changes to the visibility are updated through the application settings
+	if !data.Visibility.IsUnknown() && !data.Visibility.Equal(state.Visibility) {
+		opts := loriot.LoRaApplicationApi1NwkAppAPPIDPostOpts{
+			Body: optional.NewInterface(loriot.AppAppidBody{
+				Visibility: data.Visibility.ValueString(),
+			}),
+		}
 
-		capacityBody := loriot.AppidCapacityBody{
-			Inc: 0,
-			Dec: 0,
+		httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDPost(ctx, state.AppId.ValueString(), &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update visibility of App", httpResp, err))
+			return
 		}
+	}
 
-		capacityBody.Inc = data.DevicesLimit.ValueFloat64() - state.DecimalId.ValueFloat64()
-		capacityBody.Dec = state.DevicesLimit.ValueFloat64() - data.DecimalId.ValueFloat64()
+	// The capacity API changes the device limit by an amount, rather than
+	// setting it, so the difference from the current capacity is requested
+	change := allocatedCapacity(data.DevicesLimit.ValueFloat64()) - allocatedCapacity(state.DevicesLimit.ValueFloat64())
+	if change != 0 {
+		capacityBody := loriot.AppidCapacityBody{}
 
-		if capacityBody.Inc < 0 {
-			capacityBody.Inc = 0
+		if change > 0 {
+			capacityBody.Inc = change
+		} else {
+			capacityBody.Dec = -change
 		}
 
-		if capacityBody.Dec < 0 {
-			capacityBody.Dec = 0
+		httpResp, capacityErr := r.client.LoRaApplicationApi.V1NwkAppAPPIDCapacityPost(ctx, capacityBody, state.AppId.ValueString())
+
+		if capacityErr != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update capacity of App", httpResp, capacityErr))
+			return
 		}
+	}
 
-		_, capacityErr := r.client.LoRaApplicationApi.V1NwkAppAPPIDCapacityPost(ctx, capacityBody, state.AppId.ValueString())
+	if !data.MCastDevicesLimit.Equal(state.MCastDevicesLimit) {
+		resp.Diagnostics.Append(r.updateMCastDevicesLimit(ctx, state.AppId.ValueString(), data.MCastDevicesLimit.ValueFloat64())...)
 
-		if capacityErr != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update capacity of App, got error: %s", capacityErr))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.ConfigDeviceBase.Equal(state.ConfigDeviceBase) && !data.ConfigDeviceBase.IsNull() && !data.ConfigDeviceBase.IsUnknown() {
+		resp.Diagnostics.Append(r.updateConfigDeviceBase(ctx, state.AppId.ValueString(), data.ConfigDeviceBase)...)
+
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
 	// Re-read the application to ensure the most up-to-date version is returned
-	app, _, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, state.AppId.ValueString())
+	app, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDGet(ctx, state.AppId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
 		return
 	}
 
@@ -286,12 +542,15 @@ func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	data.Name = types.StringValue(app.Name)
 	data.OwnerId = types.Float64Value(app.Ownerid)
 	data.OrganizationId = types.Float64Value(app.OrganizationId)
-	//visibility
+	data.Visibility = types.StringValue(app.Visibility)
 	data.CreatedDate = types.StringValue(app.Created)
 	data.DevicesUsed = types.Float64Value(app.Devices)
-	data.DevicesLimit = types.Float64Value(app.DeviceLimit)
+	data.DevicesLimit = devicesLimitValue(data.DevicesLimit, app.DeviceLimit)
 	data.MCastDevicesUsed = types.Float64Value(app.Mcastdevices)
 	data.MCastDevicesLimit = types.Float64Value(app.Mcastdevlimit)
+	configDeviceBase, diags := appConfigDeviceBaseValue(app.CfgDevBase)
+	resp.Diagnostics.Append(diags...)
+	data.ConfigDeviceBase = configDeviceBase
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -307,9 +566,10 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	_, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDDelete(ctx, data.AppId.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete App, got error: %s", err))
+	// The App may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDDelete(ctx, data.AppId.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete App", httpResp, err))
 		return
 	}
 }
@@ -317,3 +577,171 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 func (r *AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("app_id"), req, resp)
 }
+
+// updateMCastDevicesLimit sets the multicast device limit of the application.
+func (r *AppResource) updateMCastDevicesLimit(ctx context.Context, appId string, limit float64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The generated client does not send a body to this API, so the request
+	// is made directly with the configured HTTP client
+	httpResp, err := r.client.do(ctx, http.MethodPost, "/1/nwk/app/"+url.PathEscape(appId)+"/mcastdevlimit", appMcastDevLimitBody{Mcastdevlimit: limit}, nil)
+	if err != nil {
+		diags.AddError(clientError("Unable to update multicast device limit of App", httpResp, err))
+	}
+
+	return diags
+}
+
+// updateConfigDeviceBase sets the device base configuration of the application
+// to the known values in config.
+func (r *AppResource) updateConfigDeviceBase(ctx context.Context, appId string, config types.Object) diag.Diagnostics {
+	var model AppConfigDeviceBaseResourceModel
+
+	diags := config.As(ctx, &model, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	body := loriot.ApplicationCfgDevBase{
+		Devclass:    model.DeviceClass.ValueStringPointer(),
+		Rxw:         int32Pointer(model.RxW),
+		Dutycycle:   int32Pointer(model.DutyCycle),
+		Adr:         model.Address.ValueBoolPointer(),
+		AdrMin:      int32Pointer(model.AddressMin),
+		AdrMax:      int32Pointer(model.AddressMax),
+		AdrFix:      int32Pointer(model.AddressFix),
+		Seqrelax:    model.SequenceRelax.ValueBoolPointer(),
+		Seqdnreset:  invertBoolPointer(model.SequenceDoNotReset.ValueBoolPointer()),
+		AdrCntLimit: int32Pointer(model.AddressCountLimit),
+	}
+
+	opts := loriot.LoRaDevicesApi1NwkAppAPPIDCfgDevBasePutOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDCfgDevBasePut(ctx, false, appId, &opts)
+	if err != nil {
+		diags.AddError(clientError("Unable to update device base configuration of App", httpResp, err))
+	}
+
+	return diags
+}
+
+// appConfigDeviceBaseValue converts the device base configuration returned by
+// the API into an object value.
+func appConfigDeviceBaseValue(cfg *loriot.ApplicationCfgDevBase) (types.Object, diag.Diagnostics) {
+	attrTypes := AppConfigDeviceBaseResourceModel{}.attrTypes()
+
+	if cfg == nil {
+		return types.ObjectNull(attrTypes), nil
+	}
+
+	return types.ObjectValue(attrTypes, map[string]attr.Value{
+		"device_class":          types.StringPointerValue(cfg.Devclass),
+		"rxw":                   int64PointerValue(cfg.Rxw),
+		"duty_cycle":            int64PointerValue(cfg.Dutycycle),
+		"address":               types.BoolPointerValue(cfg.Adr),
+		"address_min":           int64PointerValue(cfg.AdrMin),
+		"address_max":           int64PointerValue(cfg.AdrMax),
+		"address_fix":           int64PointerValue(cfg.AdrFix),
+		"sequence_relax":        types.BoolPointerValue(cfg.Seqrelax),
+		"sequence_do_not_reset": types.BoolPointerValue(invertBoolPointer(cfg.Seqdnreset)),
+		"address_count_limit":   int64PointerValue(cfg.AdrCntLimit),
+	})
+}
+
+// invertBoolPointer negates an optional boolean. The API flag which resets the
+// downlink sequence number is the inverse of sequence_do_not_reset.
+func invertBoolPointer(value *bool) *bool {
+	if value == nil {
+		return nil
+	}
+
+	inverted := !*value
+
+	return &inverted
+}
+
+// int64PointerValue converts an optional 32-bit integer returned by the API.
+func int64PointerValue(value *int32) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*value))
+}
+
+// int32Pointer converts a known Terraform integer into an optional 32-bit
+// integer for the API, returning nil for null or unknown values.
+func int32Pointer(value types.Int64) *int32 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	v := int32(value.ValueInt64())
+	return &v
+}
+
+// appCapacityStep is the number of devices capacity is allocated in.
+const appCapacityStep = 10
+
+// allocatedCapacity returns the capacity allocated for a device limit, which
+// is rounded up to a multiple of appCapacityStep.
+func allocatedCapacity(limit float64) float64 {
+	return math.Ceil(limit/appCapacityStep) * appCapacityStep
+}
+
+// devicesLimitValue returns the device limit for the capacity of an
+// application. The configured limit is kept if it was rounded up to the
+// capacity, so it does not show as changed.
+func devicesLimitValue(limit types.Float64, capacity float64) types.Float64 {
+	if !limit.IsNull() && !limit.IsUnknown() && allocatedCapacity(limit.ValueFloat64()) == capacity {
+		return limit
+	}
+
+	return types.Float64Value(capacity)
+}
+
+// capacityValidator checks an application limit is a whole number of at least
+// 0, and warns when it is rounded up to a multiple of step.
+type capacityValidator struct {
+	step float64
+}
+
+func (v capacityValidator) Description(ctx context.Context) string {
+	if v.step == 1 {
+		return "value must be a whole number of at least 0"
+	}
+
+	return fmt.Sprintf("value must be a whole number of at least 0, and is rounded up to a multiple of %g", v.step)
+}
+
+func (v capacityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v capacityValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueFloat64()
+
+	if value < 0 || value != math.Trunc(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Application Limit",
+			fmt.Sprintf("The value %g is not valid, %s.", value, v.Description(ctx)),
+		)
+
+		return
+	}
+
+	if math.Mod(value, v.step) != 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Application Limit Rounded Up",
+			fmt.Sprintf("Capacity is allocated in blocks of %g, so capacity for %g devices will be allocated for the limit of %g.", v.step, math.Ceil(value/v.step)*v.step, value),
+		)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAppResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccAppResourceConfig("one", "private", "A", 10, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app.test", "name", "one"),
+					resource.TestCheckResourceAttr("loriot_app.test", "app_id", "BE010000"),
+					resource.TestCheckResourceAttr("loriot_app.test", "devices_limit", "10"),
+					resource.TestCheckResourceAttr("loriot_app.test", "visibility", "private"),
+					resource.TestCheckResourceAttr("loriot_app.test", "organization_id", "1"),
+					resource.TestCheckResourceAttr("loriot_app.test", "config_device_base.device_class", "A"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_app.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_app.test", "app_id"),
+				ImportStateVerifyIdentifierAttribute: "app_id",
+			},
+			// Capacity rounding testing
+			{
+				Config: server.providerConfig() + testAccAppResourceConfig("one", "private", "A", 15, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app.test", "devices_limit", "15"),
+					testCheckCapacity(server, "BE010000", 20),
+				),
+			},
+			// Capacity validation testing
+			{
+				Config:      server.providerConfig() + testAccAppResourceConfig("one", "private", "A", -10, 0),
+				ExpectError: regexp.MustCompile(`Invalid Application Limit`),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccAppResourceConfig("two", "public", "C", 30, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app.test", "name", "two"),
+					resource.TestCheckResourceAttr("loriot_app.test", "app_id", "BE010000"),
+					resource.TestCheckResourceAttr("loriot_app.test", "visibility", "public"),
+					resource.TestCheckResourceAttr("loriot_app.test", "devices_limit", "30"),
+					resource.TestCheckResourceAttr("loriot_app.test", "mcast_devices_limit", "5"),
+					resource.TestCheckResourceAttr("loriot_app.test", "config_device_base.device_class", "C"),
+				),
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					if _, err := server.client().LoRaApplicationApi.V1NwkAppAPPIDDelete(context.Background(), "BE010000"); err != nil {
+						t.Fatalf("unable to delete App: %s", err)
+					}
+				},
+				Config:             server.providerConfig() + testAccAppResourceConfig("two", "public", "C", 30, 5),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testCheckCapacity checks the device capacity allocated to an application.
+func testCheckCapacity(server *mockLoriotServer, appId string, want float64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		app, _, err := server.client().LoRaApplicationApi.V1NwkAppAPPIDGet(context.Background(), appId)
+		if err != nil {
+			return err
+		}
+
+		if app.DeviceLimit != want {
+			return fmt.Errorf("expected capacity %g, got %g", want, app.DeviceLimit)
+		}
+
+		return nil
+	}
+}
+
+func TestAccAppResource_sequenceDoNotReset(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	// testCheckSeqdnreset checks the flag sent to the API, which is the
+	// inverse of sequence_do_not_reset.
+	testCheckSeqdnreset := func(want bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			app, _, err := server.client().LoRaApplicationApi.V1NwkAppAPPIDGet(context.Background(), "BE010000")
+			if err != nil {
+				return err
+			}
+
+			if app.CfgDevBase == nil || app.CfgDevBase.Seqdnreset == nil || *app.CfgDevBase.Seqdnreset != want {
+				return fmt.Errorf("expected seqdnreset %t, got %+v", want, app.CfgDevBase)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccAppResourceSequenceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app.test", "config_device_base.sequence_do_not_reset", "true"),
+					testCheckSeqdnreset(false),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccAppResourceSequenceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_app.test", "config_device_base.sequence_do_not_reset", "false"),
+					testCheckSeqdnreset(true),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAppResourceConfig(name string, visibility string, deviceClass string, devicesLimit int, mcastDevicesLimit int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = %[1]q
+  visibility          = %[2]q
+  devices_limit       = %[4]d
+  mcast_devices_limit = %[5]d
+
+  config_device_base = {
+    device_class = %[3]q
+  }
+}
+`, name, visibility, deviceClass, devicesLimit, mcastDevicesLimit)
+}
+
+func testAccAppResourceSequenceConfig(doNotReset bool) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+
+  config_device_base = {
+    sequence_do_not_reset = %[1]t
+  }
+}
+`, doNotReset)
+}
+
+func TestCapacityValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       float64
+		wantError   bool
+		wantWarning bool
+	}{
+		"multiple":  {value: 30},
+		"zero":      {value: 0},
+		"rounded":   {value: 25, wantWarning: true},
+		"fraction":  {value: 12.5, wantError: true},
+		"negative":  {value: -10, wantError: true},
+		"small":     {value: 1, wantWarning: true},
+		"hundreds":  {value: 1000},
+		"near-zero": {value: 9, wantWarning: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.Float64Request{
+				Path:        path.Root("devices_limit"),
+				ConfigValue: types.Float64Value(testCase.value),
+			}
+			resp := &validator.Float64Response{}
+
+			capacityValidator{step: appCapacityStep}.ValidateFloat64(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount() > 0; got != testCase.wantError {
+				t.Errorf("ValidateFloat64(%g) error = %t, want %t: %v", testCase.value, got, testCase.wantError, resp.Diagnostics)
+			}
+
+			if got := resp.Diagnostics.WarningsCount() > 0; got != testCase.wantWarning {
+				t.Errorf("ValidateFloat64(%g) warning = %t, want %t: %v", testCase.value, got, testCase.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDevicesLimitValue(t *testing.T) {
+	testCases := map[string]struct {
+		limit    types.Float64
+		capacity float64
+		want     types.Float64
+	}{
+		"exact":    {limit: types.Float64Value(30), capacity: 30, want: types.Float64Value(30)},
+		"rounded":  {limit: types.Float64Value(25), capacity: 30, want: types.Float64Value(25)},
+		"changed":  {limit: types.Float64Value(25), capacity: 40, want: types.Float64Value(40)},
+		"imported": {limit: types.Float64Null(), capacity: 30, want: types.Float64Value(30)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := devicesLimitValue(testCase.limit, testCase.capacity); !got.Equal(testCase.want) {
+				t.Errorf("devicesLimitValue(%s, %g) = %s, want %s", testCase.limit, testCase.capacity, got, testCase.want)
+			}
+		})
+	}
+}
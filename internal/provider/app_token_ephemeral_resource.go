@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &AppTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AppTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &AppTokenEphemeralResource{}
+
+// appTokenPrivateKey is the private data key holding the token minted by
+// Open, which Close revokes.
+const appTokenPrivateKey = "minted_token"
+
+func NewAppTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AppTokenEphemeralResource{}
+}
+
+// AppTokenEphemeralResource defines the ephemeral resource implementation.
+type AppTokenEphemeralResource struct {
+	client *loriotClient
+}
+
+// AppTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AppTokenEphemeralResourceModel struct {
+	AppId types.String `tfsdk:"app_id"`
+	Mint  types.Bool   `tfsdk:"mint"`
+	Token types.String `tfsdk:"token"`
+}
+
+// appTokenPrivateData is the private data passed from Open to Close.
+type appTokenPrivateData struct {
+	AppId string `json:"app_id"`
+	Token string `json:"token"`
+}
+
+func (r *AppTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_token"
+}
+
+func (r *AppTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App token ephemeral resource. The token is never written to the plan or state, so it can be passed to other providers without persisting it. Requires Terraform 1.10 or later",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+			},
+			"mint": schema.BoolAttribute{
+				MarkdownDescription: "Create a new token for the duration of the Terraform run, which is revoked when the run completes. By default, the first existing token of the application is used",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Application token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *AppTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AppTokenEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appId := data.AppId.ValueString()
+
+	if data.Mint.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("Creating short-lived Token for App %s", appId))
+
+		// The API expects a JSON body, although it has no parameters
+		token, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenPost(ctx, map[string]interface{}{}, appId)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to create App Token", httpResp, err))
+			return
+		}
+
+		private, err := json.Marshal(appTokenPrivateData{AppId: appId, Token: token.Token})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to save App Token", fmt.Sprintf("Unable to encode private data, got error: %s", err))
+			return
+		}
+
+		// Revoke the token in Close, so it only lasts for the Terraform run
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appTokenPrivateKey, private)...)
+
+		data.Token = types.StringValue(token.Token)
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Fetching Tokens of App %s", appId))
+
+		tokens, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(ctx, appId)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to read App Tokens", httpResp, err))
+			return
+		}
+
+		if len(tokens) == 0 {
+			resp.Diagnostics.AddError(
+				"App Token Not Found",
+				fmt.Sprintf("App %s has no tokens. Create one with the loriot_app_token resource, or set mint to create a short-lived token.", appId),
+			)
+			return
+		}
+
+		data.Token = types.StringValue(tokens[0])
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "opened an ephemeral resource")
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *AppTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, appTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	// Existing tokens are left as they are
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var data appTokenPrivateData
+	if err := json.Unmarshal(private, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to revoke App Token", fmt.Sprintf("Unable to decode private data, got error: %s", err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Revoking short-lived Token of App %s", data.AppId))
+
+	// The Token, or its App, may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenTOKENDelete(ctx, data.AppId, data.Token)
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to revoke App Token", httpResp, err))
+		return
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAppTokenEphemeralResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+	client := server.client()
+
+	opts := loriot.LoRaApplicationApi1NwkAppsPostOpts{
+		Body: optional.NewInterface(appCreateBody{
+			NwkAppsBody: loriot.NwkAppsBody{Title: "test", Capacity: 10, Visibility: "private"},
+		}),
+	}
+
+	app, _, err := client.LoRaApplicationApi.V1NwkAppsPost(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("unable to create App: %s", err)
+	}
+
+	// testCheckTokens checks the number of tokens of the application, to
+	// ensure minted tokens are revoked when the run completes.
+	testCheckTokens := func(want int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			tokens, _, err := client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(context.Background(), app.AppHexId)
+			if err != nil {
+				return err
+			}
+
+			if len(tokens) != want {
+				return fmt.Errorf("got %d App Tokens, want %d", len(tokens), want)
+			}
+
+			return nil
+		}
+	}
+
+	// testCheckToken checks the token echoed into resourceName is the given
+	// token of the application.
+	testCheckToken := func(resourceName string, index int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			tokens, _, err := client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(context.Background(), app.AppHexId)
+			if err != nil {
+				return err
+			}
+
+			if len(tokens) <= index {
+				return fmt.Errorf("got %d App Tokens, want at least %d", len(tokens), index+1)
+			}
+
+			return resource.TestCheckResourceAttr(resourceName, "data.token", tokens[index])(s)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0"))),
+		},
+		PreCheck: func() { testAccPreCheck(t) },
+		// The token is passed through the echo provider, as aliases of the
+		// provider share a single instance in tests, so cannot be configured
+		// with different credentials.
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"loriot": testAccProtoV6ProviderFactories["loriot"],
+			"echo":   echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			// Existing tokens are required unless minting
+			{
+				Config:      server.providerConfig() + testAccAppTokenEphemeralResourceConfig(app.AppHexId, false),
+				ExpectError: regexp.MustCompile(`has no tokens`),
+			},
+			// Minted tokens are revoked afterwards
+			{
+				Config: server.providerConfig() + testAccAppTokenEphemeralResourceConfig(app.AppHexId, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("echo.minted", "data.token", regexp.MustCompile(`^vnoc`)),
+					testCheckTokens(0),
+				),
+			},
+			// Existing tokens are left as they are
+			{
+				PreConfig: func() {
+					if _, _, err := client.LoRaApplicationApi.V1NwkAppAPPIDTokenPost(context.Background(), map[string]interface{}{}, app.AppHexId); err != nil {
+						t.Fatalf("unable to create App Token: %s", err)
+					}
+				},
+				Config: server.providerConfig() + testAccAppTokenEphemeralResourceConfig(app.AppHexId, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckToken("echo.existing", 0),
+					testCheckTokens(1),
+				),
+			},
+		},
+	})
+}
+
+// testAccAppTokenEphemeralResourceConfig echoes the ephemeral token into the
+// state of the echo.minted or echo.existing resource. Echo resources keep the
+// data they were created with, so each kind of token has its own resource.
+func testAccAppTokenEphemeralResourceConfig(appId string, mint bool) string {
+	name := "existing"
+	if mint {
+		name = "minted"
+	}
+
+	return fmt.Sprintf(`
+ephemeral "loriot_app_token" "test" {
+  app_id = %[1]q
+  mint   = %[2]t
+}
+
+provider "echo" {
+  data = ephemeral.loriot_app_token.test
+}
+
+resource "echo" %[3]q {}
+`, appId, mint, name)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppTokenResource{}
+var _ resource.ResourceWithImportState = &AppTokenResource{}
+
+func NewAppTokenResource() resource.Resource {
+	return &AppTokenResource{}
+}
+
+// AppTokenResource defines the resource implementation.
+type AppTokenResource struct {
+	client *loriotClient
+}
+
+// AppTokenResourceModel describes the resource data model.
+type AppTokenResourceModel struct {
+	AppId types.String `tfsdk:"app_id"`
+	Token types.String `tfsdk:"token"`
+}
+
+func (r *AppTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_token"
+}
+
+func (r *AppTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Application token resource. A new token is generated on creation and revoked on destruction. An existing token is imported with the identifier `app_id/token`, which includes the token itself, so it is kept in shell history and logs when given to `terraform import`. Prefer an `import` block taking the token from a sensitive variable",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Application token",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AppTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AppTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API expects a JSON body, although it has no parameters
+	token, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenPost(ctx, map[string]interface{}{}, data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create App Token", httpResp, err))
+		return
+	}
+
+	data.Token = types.StringValue(token.Token)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AppTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Tokens of App %s", data.AppId.ValueString()))
+
+	tokens, httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(ctx, data.AppId.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("App %s no longer exists, removing Token from state", data.AppId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read App Tokens", httpResp, err))
+		return
+	}
+
+	found := false
+	for _, token := range tokens {
+		if token == data.Token.ValueString() {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Token no longer exists in App %s, removing from state", data.AppId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AppTokenResourceModel
+
+	// All attributes require replacement, so there is nothing to update
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AppTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Token, or its App, may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaApplicationApi.V1NwkAppAPPIDTokenTOKENDelete(ctx, data.AppId.ValueString(), data.Token.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to revoke App Token", httpResp, err))
+		return
+	}
+}
+
+func (r *AppTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/token. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("token"), idParts[1])...)
+}
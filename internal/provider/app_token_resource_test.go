@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppTokenResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccAppTokenResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("loriot_app_token.test", "app_id", "loriot_app.test", "app_id"),
+					resource.TestCheckResourceAttr("loriot_app_token.test", "token", "vnoc0000000000000000000000000001"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_app_token.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_app_token.test", "app_id", "token"),
+				ImportStateVerifyIdentifierAttribute: "token",
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccAppTokenResourceConfig = `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_app_token" "test" {
+  app_id = loriot_app.test.app_id
+}
+`
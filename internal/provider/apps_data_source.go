@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &AppsDataSource{}
+	_ datasource.DataSourceWithConfigure = &AppsDataSource{}
+)
+
+// appsPageSize is the number of applications requested per page.
+const appsPageSize = 100
+
+func NewAppsDataSource() datasource.DataSource {
+	return &AppsDataSource{}
+}
+
+// AppsDataSource defines the data source implementation.
+type AppsDataSource struct {
+	client *loriotClient
+}
+
+// AppsDataSourceModel describes the data source data model.
+type AppsDataSourceModel struct {
+	NameRegex      types.String             `tfsdk:"name_regex"`
+	OrganizationId types.Float64            `tfsdk:"organization_id"`
+	OwnerId        types.Float64            `tfsdk:"owner_id"`
+	Visibility     types.String             `tfsdk:"visibility"`
+	Apps           []AppsDataSourceAppModel `tfsdk:"apps"`
+}
+
+type AppsDataSourceAppModel struct {
+	AppId             types.String  `tfsdk:"app_id"`
+	DecimalId         types.Float64 `tfsdk:"decimal_id"`
+	Name              types.String  `tfsdk:"name"`
+	OwnerId           types.Float64 `tfsdk:"owner_id"`
+	OrganizationId    types.Float64 `tfsdk:"organization_id"`
+	Visibility        types.String  `tfsdk:"visibility"`
+	CreatedDate       types.String  `tfsdk:"created_date"`
+	DevicesUsed       types.Float64 `tfsdk:"devices_used"`
+	DevicesLimit      types.Float64 `tfsdk:"devices_limit"`
+	MCastDevicesUsed  types.Float64 `tfsdk:"mcast_devices_used"`
+	MCastDevicesLimit types.Float64 `tfsdk:"mcast_devices_limit"`
+}
+
+func (d *AppsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *AppsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source listing the applications visible to the API key",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the application name must match",
+				Optional:            true,
+				Validators: []validator.String{
+					regexpValidator{},
+				},
+			},
+			"organization_id": schema.Float64Attribute{
+				MarkdownDescription: "Identifier of the organization the applications must belong to",
+				Optional:            true,
+			},
+			"owner_id": schema.Float64Attribute{
+				MarkdownDescription: "User ID of the owner the applications must belong to",
+				Optional:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Visibility the applications must have",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(appVisibilityValues...),
+				},
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "Applications matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"app_id": schema.StringAttribute{
+							MarkdownDescription: "Application ID in hexadecimal format",
+							Computed:            true,
+						},
+						"decimal_id": schema.Float64Attribute{
+							MarkdownDescription: "Application ID in decimal format",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Application name",
+							Computed:            true,
+						},
+						"owner_id": schema.Float64Attribute{
+							MarkdownDescription: "User ID of the application owner",
+							Computed:            true,
+						},
+						"organization_id": schema.Float64Attribute{
+							MarkdownDescription: "Identifier of the organization the application belongs to",
+							Computed:            true,
+						},
+						"visibility": schema.StringAttribute{
+							MarkdownDescription: "Visibility of the application",
+							Computed:            true,
+						},
+						"created_date": schema.StringAttribute{
+							MarkdownDescription: "Creation date",
+							Computed:            true,
+						},
+						"devices_used": schema.Float64Attribute{
+							MarkdownDescription: "Number of devices registered with the application",
+							Computed:            true,
+						},
+						"devices_limit": schema.Float64Attribute{
+							MarkdownDescription: "Limit of devices which can be registered",
+							Computed:            true,
+						},
+						"mcast_devices_used": schema.Float64Attribute{
+							MarkdownDescription: "Number of multicast devices registered with the application",
+							Computed:            true,
+						},
+						"mcast_devices_limit": schema.Float64Attribute{
+							MarkdownDescription: "Limit of multicast devices which can be registered",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AppsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		if nameRegex, err = regexp.Compile(data.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The value %q is not a valid regular expression: %s", data.NameRegex.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	data.Apps = []AppsDataSourceAppModel{}
+
+	for page := float64(1); ; page++ {
+		tflog.Info(ctx, fmt.Sprintf("Fetching page %.0f of Apps", page))
+
+		opts := loriot.LoRaApplicationApi1NwkAppsGetOpts{
+			Page:    optional.NewFloat64(page),
+			PerPage: optional.NewFloat64(appsPageSize),
+		}
+
+		result, httpResp, err := d.client.LoRaApplicationApi.V1NwkAppsGet(ctx, &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to list Apps", httpResp, err))
+			return
+		}
+
+		if result.Apps == nil || len(*result.Apps) == 0 {
+			break
+		}
+
+		for _, app := range *result.Apps {
+			if nameRegex != nil && !nameRegex.MatchString(app.Name) {
+				continue
+			}
+
+			if !data.OrganizationId.IsNull() && app.OrganizationId != data.OrganizationId.ValueFloat64() {
+				continue
+			}
+
+			if !data.OwnerId.IsNull() && app.Ownerid != data.OwnerId.ValueFloat64() {
+				continue
+			}
+
+			if !data.Visibility.IsNull() && app.Visibility != data.Visibility.ValueString() {
+				continue
+			}
+
+			data.Apps = append(data.Apps, AppsDataSourceAppModel{
+				AppId:             types.StringValue(app.AppHexId),
+				DecimalId:         types.Float64Value(app.Id),
+				Name:              types.StringValue(app.Name),
+				OwnerId:           types.Float64Value(app.Ownerid),
+				OrganizationId:    types.Float64Value(app.OrganizationId),
+				Visibility:        types.StringValue(app.Visibility),
+				CreatedDate:       types.StringValue(app.Created),
+				DevicesUsed:       types.Float64Value(app.Devices),
+				DevicesLimit:      types.Float64Value(app.DeviceLimit),
+				MCastDevicesUsed:  types.Float64Value(app.Mcastdevices),
+				MCastDevicesLimit: types.Float64Value(app.Mcastdevlimit),
+			})
+		}
+
+		if page*appsPageSize >= result.Total {
+			break
+		}
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// regexpValidator validates that a string is a valid regular expression.
+type regexpValidator struct{}
+
+func (v regexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q is not a valid regular expression: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppsDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccAppsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_apps.all", "apps.#", "2"),
+					resource.TestCheckResourceAttr("data.loriot_apps.filtered", "apps.#", "1"),
+					resource.TestCheckResourceAttr("data.loriot_apps.filtered", "apps.0.name", "sensors"),
+					resource.TestCheckResourceAttr("data.loriot_apps.filtered", "apps.0.visibility", "public"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAppsDataSourceConfig = `
+resource "loriot_app" "sensors" {
+  name                = "sensors"
+  visibility          = "public"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_app" "trackers" {
+  name                = "trackers"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+data "loriot_apps" "all" {
+  depends_on = [loriot_app.sensors, loriot_app.trackers]
+}
+
+data "loriot_apps" "filtered" {
+  name_regex = "^sens"
+  visibility = "public"
+
+  depends_on = [loriot_app.sensors, loriot_app.trackers]
+}
+`
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,13 +22,14 @@ func NewAppTokenDataSource() datasource.DataSource {
 
 // AppTokenDataSource defines the data source implementation.
 type AppTokenDataSource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // AppTokenDataSourceModel describes the data source data model.
 type AppTokenDataSourceModel struct {
-	AppId types.String `tfsdk:"app_id"`
-	Token types.String `tfsdk:"token"`
+	AppId  types.String   `tfsdk:"app_id"`
+	Token  types.String   `tfsdk:"token"`
+	Tokens []types.String `tfsdk:"tokens"`
 }
 
 func (d *AppTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,7 +39,7 @@ func (d *AppTokenDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *AppTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Data source for the token for an application",
+		MarkdownDescription: "Data source for the tokens of an application",
 
 		Attributes: map[string]schema.Attribute{
 			"app_id": schema.StringAttribute{
@@ -47,8 +47,15 @@ func (d *AppTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Required:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Application token",
+				MarkdownDescription: "First application token",
 				Computed:            true,
+				Sensitive:           true,
+			},
+			"tokens": schema.ListAttribute{
+				MarkdownDescription: "All application tokens",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
 			},
 		},
 	}
@@ -60,12 +67,12 @@ func (d *AppTokenDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *loriot.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -86,17 +93,22 @@ func (d *AppTokenDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tflog.Info(ctx, fmt.Sprintf("Fetching App Token with App ID: %s", data.AppId.ValueString()))
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	appToken, _, err := d.client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(ctx, data.AppId.ValueString())
+	appTokens, httpResp, err := d.client.LoRaApplicationApi.V1NwkAppAPPIDTokenGet(ctx, data.AppId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App Token, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read App Token", httpResp, err))
 		return
 	}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Token = types.StringValue(appToken[0])
+	if len(appTokens) > 0 {
+		data.Token = types.StringValue(appTokens[0])
+	} else {
+		data.Token = types.StringNull()
+	}
+
+	data.Tokens = make([]types.String, 0, len(appTokens))
+	for _, token := range appTokens {
+		data.Tokens = append(data.Tokens, types.StringValue(token))
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppTokenDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccAppTokenDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_apptoken.test", "tokens.#", "2"),
+					resource.TestCheckResourceAttrPair("data.loriot_apptoken.test", "token", "loriot_app_token.first", "token"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAppTokenDataSourceConfig = `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_app_token" "first" {
+  app_id = loriot_app.test.app_id
+}
+
+resource "loriot_app_token" "second" {
+  app_id = loriot_app.test.app_id
+
+  depends_on = [loriot_app_token.first]
+}
+
+data "loriot_apptoken" "test" {
+  app_id = loriot_app.test.app_id
+
+  depends_on = [loriot_app_token.first, loriot_app_token.second]
+}
+`
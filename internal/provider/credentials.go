@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// defaultCredentialsFile is the credentials file read when the provider
+	// does not configure credentials_file, relative to the home directory.
+	defaultCredentialsFile = ".loriot/credentials"
+
+	// defaultProfile is the credentials file profile used when the provider
+	// does not configure profile.
+	defaultProfile = "default"
+)
+
+// errProfileNotFound is returned when the credentials file has no section for
+// the profile.
+var errProfileNotFound = errors.New("profile not found")
+
+// loriotCredentials are the settings of a credentials file profile.
+type loriotCredentials struct {
+	Host     string
+	Region   string
+	APIKey   string
+	AppToken string
+}
+
+// hasAuthentication reports whether any of the credentials used to
+// authenticate are set.
+func (c loriotCredentials) hasAuthentication() bool {
+	return c.APIKey != "" || c.AppToken != ""
+}
+
+// readCredentialsFile returns the settings of profile in the credentials file
+// at path. The file holds a section of settings for each profile:
+//
+//	[default]
+//	region = eu1
+//	key    = AAAA...
+//
+//	[reader]
+//	host      = https://loriot.example.com
+//	app_token = ...
+//
+// Lines starting with # or ; are comments.
+func readCredentialsFile(path string, profile string) (loriotCredentials, error) {
+	var credentials loriotCredentials
+
+	file, err := os.Open(path)
+	if err != nil {
+		return credentials, err
+	}
+	defer file.Close()
+
+	section := ""
+	found := false
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			found = found || section == profile
+			continue
+		}
+
+		name, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return credentials, fmt.Errorf("%s:%d: expected name = value", path, line)
+		}
+
+		if section != profile {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(name) {
+		case "host":
+			credentials.Host = value
+		case "region":
+			credentials.Region = value
+		case "key":
+			credentials.APIKey = value
+		case "app_token":
+			credentials.AppToken = value
+		default:
+			return credentials, fmt.Errorf("%s:%d: unknown setting %q", path, line, strings.TrimSpace(name))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return credentials, err
+	}
+
+	if !found {
+		return credentials, fmt.Errorf("%w: %q in %s", errProfileNotFound, profile, path)
+	}
+
+	return credentials, nil
+}
+
+// defaultCredentialsPath returns the path of the credentials file in the home
+// directory of the user.
+func defaultCredentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, defaultCredentialsFile), nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCredentialsFile = `
+# Default profile authenticating with an API key
+[default]
+host = https://eu1.loriot.io
+key  = AAAA
+
+; Administrator on another cluster
+[admin]
+host = https://us1.loriot.io
+key  = BBBB = with equals
+
+[reader]
+app_token = vnoc0001
+`
+
+func TestReadCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(testCredentialsFile), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		profile string
+		want    loriotCredentials
+	}{
+		"default": {
+			profile: "default",
+			want:    loriotCredentials{Host: "https://eu1.loriot.io", APIKey: "AAAA"},
+		},
+		"admin": {
+			profile: "admin",
+			want:    loriotCredentials{Host: "https://us1.loriot.io", APIKey: "BBBB = with equals"},
+		},
+		"app-token": {
+			profile: "reader",
+			want:    loriotCredentials{AppToken: "vnoc0001"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := readCredentialsFile(path, testCase.profile)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %+v, want %+v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestReadCredentialsFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := map[string]struct {
+		content string
+		profile string
+		wantErr string
+	}{
+		"missing-profile": {
+			content: "[default]\nkey = AAAA\n",
+			profile: "admin",
+			wantErr: `profile not found: "admin"`,
+		},
+		"unknown-setting": {
+			content: "[default]\ntoken = AAAA\n",
+			profile: "default",
+			wantErr: `unknown setting "token"`,
+		},
+		"invalid-line": {
+			content: "[default]\nkey\n",
+			profile: "default",
+			wantErr: "expected name = value",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(testCase.content), 0o600); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, err := readCredentialsFile(path, testCase.profile)
+			if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+				t.Errorf("got error %v, want %q", err, testCase.wantErr)
+			}
+		})
+	}
+
+	if _, err := readCredentialsFile(filepath.Join(dir, "missing"), "default"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v for missing file, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = DecimalToEUIFunction{}
+)
+
+func NewDecimalToEUIFunction() function.Function {
+	return DecimalToEUIFunction{}
+}
+
+type DecimalToEUIFunction struct{}
+
+func (r DecimalToEUIFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decimal_to_eui"
+}
+
+func (r DecimalToEUIFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a decimal number to an EUI",
+		MarkdownDescription: "Converts a decimal number between 0 and 18446744073709551615 to an EUI in the canonical form used by Loriot, 16 uppercase hexadecimal characters.",
+		Parameters: []function.Parameter{
+			function.NumberParameter{
+				Name:                "number",
+				MarkdownDescription: "Value of the EUI as a decimal number",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r DecimalToEUIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var number *big.Float
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &number))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := decimalToUint64(number)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid EUI: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, formatEUI(value)))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDecimalToEUIFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::decimal_to_eui(256)
+				}
+
+				output "round_trip" {
+					value = provider::loriot::decimal_to_eui(provider::loriot::eui_to_decimal("70b3d57ed0000000"))
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("test", "0000000000000100"),
+					resource.TestCheckOutput("round_trip", "70B3D57ED0000000"),
+				),
+			},
+		},
+	})
+}
+
+func TestDecimalToEUIFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::decimal_to_eui(-1)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+between\s+0\s+and\s+18446744073709551615`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::decimal_to_eui(1.5)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+a\s+whole\s+number`),
+			},
+		},
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = DecodePHYPayloadFunction{}
+)
+
+func NewDecodePHYPayloadFunction() function.Function {
+	return DecodePHYPayloadFunction{}
+}
+
+type DecodePHYPayloadFunction struct{}
+
+// DecodePHYPayloadFunctionModel describes the result of the function.
+type DecodePHYPayloadFunctionModel struct {
+	MessageType types.String `tfsdk:"message_type"`
+	Major       types.Int64  `tfsdk:"major"`
+	MIC         types.String `tfsdk:"mic"`
+	MICValid    types.Bool   `tfsdk:"mic_valid"`
+	DevAddr     types.String `tfsdk:"devaddr"`
+	ADR         types.Bool   `tfsdk:"adr"`
+	ADRACKReq   types.Bool   `tfsdk:"adr_ack_req"`
+	ACK         types.Bool   `tfsdk:"ack"`
+	FPending    types.Bool   `tfsdk:"fpending"`
+	ClassB      types.Bool   `tfsdk:"class_b"`
+	FCnt        types.Int64  `tfsdk:"fcnt"`
+	FOpts       types.String `tfsdk:"fopts"`
+	FPort       types.Int64  `tfsdk:"fport"`
+	FRMPayload  types.String `tfsdk:"frm_payload"`
+	Payload     types.String `tfsdk:"payload"`
+	AppEUI      types.String `tfsdk:"appeui"`
+	DevEUI      types.String `tfsdk:"deveui"`
+	DevNonce    types.Int64  `tfsdk:"dev_nonce"`
+}
+
+func (r DecodePHYPayloadFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_phypayload"
+}
+
+func (r DecodePHYPayloadFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a LoRaWAN frame",
+		MarkdownDescription: "Decodes a base64 encoded LoRaWAN 1.0.x frame (PHYPayload), returning an object with the fields of its header. " +
+			"Data frames include `devaddr`, the `adr`, `adr_ack_req`, `ack`, `fpending` and `class_b` flags of FCtrl, `fcnt`, `fopts`, `fport` and the encrypted `frm_payload`. " +
+			"Join requests include `appeui`, `deveui` and `dev_nonce`. Fields which do not apply to the frame are null.\n\n" +
+			"When the network session key is given, `mic_valid` reports whether the MIC of a data frame is valid. " +
+			"When the key used to encrypt the FRMPayload is given, the network session key for FPort 0 and the application session key otherwise, `payload` is the decrypted FRMPayload. " +
+			"Binary values are returned as uppercase hexadecimal. The frame is decoded locally, and neither it nor the keys are sent to Loriot.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "phypayload",
+				MarkdownDescription: "Base64 encoded frame, as shown by Loriot and most gateways",
+			},
+			function.StringParameter{
+				Name:                "nwkskey",
+				MarkdownDescription: "AES-128 network session key in hexadecimal format, or null",
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "appskey",
+				MarkdownDescription: "AES-128 application session key in hexadecimal format, or null",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"message_type": types.StringType,
+				"major":        types.Int64Type,
+				"mic":          types.StringType,
+				"mic_valid":    types.BoolType,
+				"devaddr":      types.StringType,
+				"adr":          types.BoolType,
+				"adr_ack_req":  types.BoolType,
+				"ack":          types.BoolType,
+				"fpending":     types.BoolType,
+				"class_b":      types.BoolType,
+				"fcnt":         types.Int64Type,
+				"fopts":        types.StringType,
+				"fport":        types.Int64Type,
+				"frm_payload":  types.StringType,
+				"payload":      types.StringType,
+				"appeui":       types.StringType,
+				"deveui":       types.StringType,
+				"dev_nonce":    types.Int64Type,
+			},
+		},
+	}
+}
+
+func (r DecodePHYPayloadFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var phyPayloadValue string
+	var nwkSKeyValue, appSKeyValue types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &phyPayloadValue, &nwkSKeyValue, &appSKeyValue))
+
+	if resp.Error != nil {
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(phyPayloadValue))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid PHYPayload: must be base64 encoded: "+err.Error())
+		return
+	}
+
+	p, err := parsePHYPayload(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid PHYPayload: "+err.Error())
+		return
+	}
+
+	var nwkSKey, appSKey []byte
+
+	if !nwkSKeyValue.IsNull() {
+		if nwkSKey, err = parseAESKey(nwkSKeyValue.ValueString()); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, "Invalid NwkSKey: "+err.Error())
+			return
+		}
+	}
+
+	if !appSKeyValue.IsNull() {
+		if appSKey, err = parseAESKey(appSKeyValue.ValueString()); err != nil {
+			resp.Error = function.NewArgumentFuncError(2, "Invalid AppSKey: "+err.Error())
+			return
+		}
+	}
+
+	result := DecodePHYPayloadFunctionModel{
+		MessageType: types.StringValue(p.messageType()),
+		Major:       types.Int64Value(int64(p.Major)),
+		MIC:         types.StringValue(hexValue(p.MIC)),
+		MICValid:    types.BoolNull(),
+		DevAddr:     types.StringNull(),
+		ADR:         types.BoolNull(),
+		ADRACKReq:   types.BoolNull(),
+		ACK:         types.BoolNull(),
+		FPending:    types.BoolNull(),
+		ClassB:      types.BoolNull(),
+		FCnt:        types.Int64Null(),
+		FOpts:       types.StringNull(),
+		FPort:       types.Int64Null(),
+		FRMPayload:  types.StringNull(),
+		Payload:     types.StringNull(),
+		AppEUI:      types.StringNull(),
+		DevEUI:      types.StringNull(),
+		DevNonce:    types.Int64Null(),
+	}
+
+	switch {
+	case p.isDataFrame():
+		result.DevAddr = types.StringValue(fmt.Sprintf("%08X", p.DevAddr))
+		result.ADR = types.BoolValue(p.FCtrl&0x80 != 0)
+		result.ACK = types.BoolValue(p.FCtrl&0x20 != 0)
+		result.FCnt = types.Int64Value(int64(p.FCnt))
+		result.FOpts = types.StringValue(hexValue(p.FOpts))
+
+		// The meaning of the FCtrl bits 6 and 4 depends on the direction
+		if p.isUplink() {
+			result.ADRACKReq = types.BoolValue(p.FCtrl&0x40 != 0)
+			result.ClassB = types.BoolValue(p.FCtrl&0x10 != 0)
+		} else {
+			result.FPending = types.BoolValue(p.FCtrl&0x10 != 0)
+		}
+
+		if p.FPort != nil {
+			result.FPort = types.Int64Value(int64(*p.FPort))
+			result.FRMPayload = types.StringValue(hexValue(p.FRMPayload))
+		}
+
+		if nwkSKey != nil {
+			valid, err := p.verifyMIC(nwkSKey)
+			if err != nil {
+				resp.Error = function.NewFuncError("Unable to verify MIC: " + err.Error())
+				return
+			}
+
+			result.MICValid = types.BoolValue(valid)
+		}
+
+		payloadKey := appSKey
+		if p.FPort != nil && *p.FPort == 0 {
+			payloadKey = nwkSKey
+		}
+
+		if p.FPort != nil && payloadKey != nil {
+			payload, err := p.decryptFRMPayload(payloadKey)
+			if err != nil {
+				resp.Error = function.NewFuncError("Unable to decrypt FRMPayload: " + err.Error())
+				return
+			}
+
+			result.Payload = types.StringValue(hexValue(payload))
+		}
+	case p.MType == mTypeJoinRequest:
+		result.AppEUI = types.StringValue(formatEUI(p.AppEUI))
+		result.DevEUI = types.StringValue(formatEUI(p.DevEUI))
+		result.DevNonce = types.Int64Value(int64(p.DevNonce))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// hexValue returns binary data as uppercase hexadecimal.
+func hexValue(data []byte) string {
+	return strings.ToUpper(hex.EncodeToString(data))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDecodePHYPayloadFunction_DataFrame(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					frame = provider::loriot::decode_phypayload(
+						"QPF9vkkAAgABlUN4disR/w0=",
+						"44024241ED4CE9A68C6A8BC055233FD3",
+						"EC925802AE430CA77FD3DD73CB2CC588",
+					)
+				}
+
+				output "message_type" {
+					value = local.frame.message_type
+				}
+
+				output "devaddr" {
+					value = local.frame.devaddr
+				}
+
+				output "fcnt" {
+					value = local.frame.fcnt
+				}
+
+				output "mic_valid" {
+					value = local.frame.mic_valid
+				}
+
+				output "payload" {
+					value = local.frame.payload
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("message_type", "UnconfirmedDataUp"),
+					resource.TestCheckOutput("devaddr", "49BE7DF1"),
+					resource.TestCheckOutput("fcnt", "2"),
+					resource.TestCheckOutput("mic_valid", "true"),
+					resource.TestCheckOutput("payload", "74657374"),
+				),
+			},
+		},
+	})
+}
+
+func TestDecodePHYPayloadFunction_JoinRequest(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					frame = provider::loriot::decode_phypayload("AAAAANB+1bNwd2ZVRDMiEQDNqwECAwQ=", null, null)
+				}
+
+				output "message_type" {
+					value = local.frame.message_type
+				}
+
+				output "deveui" {
+					value = local.frame.deveui
+				}
+
+				output "devaddr_null" {
+					value = local.frame.devaddr == null
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("message_type", "JoinRequest"),
+					resource.TestCheckOutput("deveui", "0011223344556677"),
+					resource.TestCheckOutput("devaddr_null", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDecodePHYPayloadFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::decode_phypayload("not base64!", null, null)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+base64\s+encoded`),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = DeriveJoinServerKeysFunction{}
+)
+
+func NewDeriveJoinServerKeysFunction() function.Function {
+	return DeriveJoinServerKeysFunction{}
+}
+
+type DeriveJoinServerKeysFunction struct{}
+
+// DeriveJoinServerKeysFunctionModel describes the result of the function.
+type DeriveJoinServerKeysFunctionModel struct {
+	JSIntKey types.String `tfsdk:"js_int_key"`
+	JSEncKey types.String `tfsdk:"js_enc_key"`
+}
+
+func (r DeriveJoinServerKeysFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "derive_join_server_keys"
+}
+
+func (r DeriveJoinServerKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Derive LoRaWAN 1.1 join server keys",
+		MarkdownDescription: "Derives the join server integrity and encryption keys of a LoRaWAN 1.1 device from its NwkKey and DevEUI, returning an object with `js_int_key` and `js_enc_key` attributes. The keys are computed locally, and are not sent to Loriot.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "nwk_key",
+				MarkdownDescription: "AES-128 network key (NwkKey) in hexadecimal format",
+			},
+			function.StringParameter{
+				Name:                "deveui",
+				MarkdownDescription: "Device EUI in hexadecimal format",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"js_int_key": types.StringType,
+				"js_enc_key": types.StringType,
+			},
+		},
+	}
+}
+
+func (r DeriveJoinServerKeysFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var nwkKeyValue, devEUIValue string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &nwkKeyValue, &devEUIValue))
+
+	if resp.Error != nil {
+		return
+	}
+
+	nwkKey, err := parseAESKey(nwkKeyValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid NwkKey: "+err.Error())
+		return
+	}
+
+	devEUI, err := parseHexID(devEUIValue, 16)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "Invalid DevEUI: "+err.Error())
+		return
+	}
+
+	jsIntKey, jsEncKey, err := deriveJoinServerKeys(nwkKey, devEUI)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to derive join server keys: " + err.Error())
+		return
+	}
+
+	result := DeriveJoinServerKeysFunctionModel{
+		JSIntKey: types.StringValue(formatAESKey(jsIntKey)),
+		JSEncKey: types.StringValue(formatAESKey(jsEncKey)),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
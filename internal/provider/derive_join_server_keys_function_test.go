@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDeriveJoinServerKeysFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					keys = provider::loriot::derive_join_server_keys("2B7E151628AED2A6ABF7158809CF4F3C", "70B3D57ED0000001")
+				}
+
+				output "js_int_key" {
+					value = local.keys.js_int_key
+				}
+
+				output "js_enc_key" {
+					value = local.keys.js_enc_key
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("js_int_key", "CF93C3690FD96A769FAA548EB3CDE704"),
+					resource.TestCheckOutput("js_enc_key", "DCEEF1D17EB97662F884D07BC0C28A8E"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeriveJoinServerKeysFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::derive_join_server_keys("2B7E1516", "70B3D57ED0000001")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid NwkKey`),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = DeriveSessionKeysFunction{}
+)
+
+func NewDeriveSessionKeysFunction() function.Function {
+	return DeriveSessionKeysFunction{}
+}
+
+type DeriveSessionKeysFunction struct{}
+
+// DeriveSessionKeysFunctionModel describes the result of the function.
+type DeriveSessionKeysFunctionModel struct {
+	NwkSKey types.String `tfsdk:"nwkskey"`
+	AppSKey types.String `tfsdk:"appskey"`
+}
+
+func (r DeriveSessionKeysFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "derive_session_keys"
+}
+
+func (r DeriveSessionKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Derive LoRaWAN 1.0.x session keys",
+		MarkdownDescription: "Derives the network and application session keys of a LoRaWAN 1.0.x device from its AppKey and the values exchanged when it joined, returning an object with `nwkskey` and `appskey` attributes. The keys are computed locally, and are not sent to Loriot.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "app_key",
+				MarkdownDescription: "AES-128 application key (AppKey) in hexadecimal format",
+			},
+			function.StringParameter{
+				Name:                "join_nonce",
+				MarkdownDescription: "Join nonce (AppNonce) sent in the join accept, as 6 hexadecimal characters",
+			},
+			function.StringParameter{
+				Name:                "net_id",
+				MarkdownDescription: "Network identifier (NetID) sent in the join accept, as 6 hexadecimal characters",
+			},
+			function.StringParameter{
+				Name:                "dev_nonce",
+				MarkdownDescription: "Device nonce (DevNonce) sent in the join request, as 4 hexadecimal characters",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"nwkskey": types.StringType,
+				"appskey": types.StringType,
+			},
+		},
+	}
+}
+
+func (r DeriveSessionKeysFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var appKeyValue, joinNonceValue, netIdValue, devNonceValue string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &appKeyValue, &joinNonceValue, &netIdValue, &devNonceValue))
+
+	if resp.Error != nil {
+		return
+	}
+
+	appKey, err := parseAESKey(appKeyValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid AppKey: "+err.Error())
+		return
+	}
+
+	joinNonce, err := parseHexID(joinNonceValue, 6)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "Invalid JoinNonce: "+err.Error())
+		return
+	}
+
+	netId, err := parseHexID(netIdValue, 6)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, "Invalid NetID: "+err.Error())
+		return
+	}
+
+	devNonce, err := parseHexID(devNonceValue, 4)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(3, "Invalid DevNonce: "+err.Error())
+		return
+	}
+
+	nwkSKey, appSKey, err := deriveSessionKeys(appKey, joinNonce, netId, devNonce)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to derive session keys: " + err.Error())
+		return
+	}
+
+	result := DeriveSessionKeysFunctionModel{
+		NwkSKey: types.StringValue(formatAESKey(nwkSKey)),
+		AppSKey: types.StringValue(formatAESKey(appSKey)),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDeriveSessionKeysFunction_Known(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+					keys = provider::loriot::derive_session_keys("2B7E151628AED2A6ABF7158809CF4F3C", "123456", "000013", "ABCD")
+				}
+
+				output "nwkskey" {
+					value = local.keys.nwkskey
+				}
+
+				output "appskey" {
+					value = local.keys.appskey
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("nwkskey", "C670E85756697041D692598007458424"),
+					resource.TestCheckOutput("appskey", "0FF0A4657A03CA86A5740FF1DA314E3D"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeriveSessionKeysFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::loriot::derive_session_keys("2B7E151628AED2A6ABF7158809CF4F3C", "123456", "000013", "ABCDEF")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid DevNonce`),
+			},
+		},
+	})
+}
@@ -0,0 +1,525 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceABPResource{}
+var _ resource.ResourceWithImportState = &DeviceABPResource{}
+
+func NewDeviceABPResource() resource.Resource {
+	return &DeviceABPResource{}
+}
+
+// DeviceABPResource defines the resource implementation.
+type DeviceABPResource struct {
+	client *loriotClient
+}
+
+// DeviceABPResourceModel describes the resource data model.
+type DeviceABPResourceModel struct {
+	AppId              types.String `tfsdk:"app_id"`
+	DevEUI             types.String `tfsdk:"deveui"`
+	DevAddr            types.String `tfsdk:"devaddr"`
+	NwkSKey            types.String `tfsdk:"nwkskey"`
+	NwkSKeyWO          types.String `tfsdk:"nwkskey_wo"`
+	NwkSKeyWOVersion   types.Int64  `tfsdk:"nwkskey_wo_version"`
+	AppSKey            types.String `tfsdk:"appskey"`
+	AppSKeyWO          types.String `tfsdk:"appskey_wo"`
+	AppSKeyWOVersion   types.Int64  `tfsdk:"appskey_wo_version"`
+	SeqNo              types.Int64  `tfsdk:"seqno"`
+	SeqDn              types.Int64  `tfsdk:"seqdn"`
+	ResetFrameCounters types.Bool   `tfsdk:"reset_frame_counters_on_update"`
+	Title              types.String `tfsdk:"title"`
+	Description        types.String `tfsdk:"description"`
+	DeviceClass        types.String `tfsdk:"device_class"`
+	LoRaWANVersion     types.String `tfsdk:"lorawan_version"`
+}
+
+func (r *DeviceABPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_abp"
+}
+
+func (r *DeviceABPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "ABP device resource",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deveui": schema.StringAttribute{
+				MarkdownDescription: "Device EUI in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(16),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"devaddr": schema.StringAttribute{
+				MarkdownDescription: "Device address in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(8),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nwkskey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 network session key in hexadecimal format. Exactly one of `nwkskey` or `nwkskey_wo` must be set",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("nwkskey_wo")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nwkskey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 network session key in hexadecimal format, which is not stored in the plan or state. It is only sent when the device is created, which it is again when `nwkskey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("nwkskey_wo_version")),
+				},
+			},
+			"nwkskey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `nwkskey_wo`. The network session key cannot be updated, so the device is replaced when it changes",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("nwkskey_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"appskey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 application session key in hexadecimal format. Exactly one of `appskey` or `appskey_wo` must be set",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("appskey_wo")),
+				},
+			},
+			"appskey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 application session key in hexadecimal format, which is not stored in the plan or state. It is only sent when the device is created or `appskey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("appskey_wo_version")),
+				},
+			},
+			"appskey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `appskey_wo`, which must be changed to update the application session key",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("appskey_wo")),
+				},
+			},
+			"seqno": schema.Int64Attribute{
+				MarkdownDescription: "Uplink frame counter (FCntUp) of the device. The device is registered with this value, 0 by default, after which it is read from the network server as it advances with traffic. Once registered, it can only be set to 0, which resets the counter",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					frameCounterResetOnly(),
+				},
+			},
+			"seqdn": schema.Int64Attribute{
+				MarkdownDescription: "Downlink frame counter (FCntDown) of the device. The device is registered with this value, 0 by default, after which it is read from the network server as it advances with traffic. Once registered, it can only be set to 0, which resets the counter",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					frameCounterResetOnly(),
+				},
+			},
+			"reset_frame_counters_on_update": schema.BoolAttribute{
+				MarkdownDescription: "Reset the uplink and downlink frame counters whenever the device is updated",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Device title, the device EUI by default",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Device description",
+				Optional:            true,
+			},
+			"device_class": schema.StringAttribute{
+				MarkdownDescription: "Device LoRaWAN class type, one of `A`, `B` or `C`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("A"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "B", "C"),
+				},
+			},
+			"lorawan_version": schema.StringAttribute{
+				MarkdownDescription: "LoRaWAN version implemented by the device, as `major.minor` or `major.minor.revision` (for example `1.0.3`)",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(loraWANVersionRegexp, "must be formatted as major.minor or major.minor.revision"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DeviceABPResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceABPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config DeviceABPResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The title defaults to the device EUI, as it does in the Loriot UI
+	if data.Title.IsUnknown() {
+		data.Title = data.DevEUI
+	}
+
+	body := loriot.DevicesAbpBody{
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		Deveui:      data.DevEUI.ValueString(),
+		Nwkskey:     writeOnlyValue(data.NwkSKey, config.NwkSKeyWO).ValueString(),
+		Appskey:     writeOnlyValue(data.AppSKey, config.AppSKeyWO).ValueString(),
+		Devaddr:     data.DevAddr.ValueString(),
+		Seqno:       float64(data.SeqNo.ValueInt64()),
+		Seqdn:       float64(data.SeqDn.ValueInt64()),
+		Lorawan:     loraWANVersionBody(data.LoRaWANVersion),
+	}
+
+	opts := loriot.LoRaDevicesApi1NwkAppAPPIDDevicesAbpPostOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	device, httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDevicesAbpPost(ctx, data.AppId.ValueString(), &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create ABP Device", httpResp, err))
+		return
+	}
+
+	// The registration API does not accept a device class, so set it afterwards
+	// if it differs from the default class the device was registered with.
+	if device.Devclass != "" && device.Devclass != data.DeviceClass.ValueString() {
+		updateOpts := loriot.LoRaDevicesApi1NwkAppAPPIDDeviceDEVEUIPostOpts{
+			Body: optional.NewInterface(loriot.DeviceDeveuiBody{
+				Devclass: data.DeviceClass.ValueString(),
+			}),
+		}
+
+		httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIPost(ctx, data.AppId.ValueString(), data.DevEUI.ValueString(), &updateOpts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update class of ABP Device", httpResp, err))
+			return
+		}
+
+		device.Devclass = data.DeviceClass.ValueString()
+	}
+
+	data.readDevice(deviceResponse{Device: device, Title: body.Title, Description: body.Description})
+
+	if data.SeqNo.IsUnknown() {
+		data.SeqNo = types.Int64Value(int64(device.Seqno))
+	}
+
+	if data.SeqDn.IsUnknown() {
+		data.SeqDn = types.Int64Value(int64(device.Seqdn))
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceABPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeviceABPResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching ABP Device with EUI %s in App %s", data.DevEUI.ValueString(), data.AppId.ValueString()))
+
+	device, httpResp, err := r.client.getDevice(ctx, data.AppId.ValueString(), data.DevEUI.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("Device %s no longer exists in App %s, removing from state", data.DevEUI.ValueString(), data.AppId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read ABP Device", httpResp, err))
+		return
+	}
+
+	data.readDevice(device)
+
+	// The frame counters are only refreshed here, as they may advance between
+	// planning and applying a change
+	data.SeqNo = types.Int64Value(int64(device.Seqno))
+	data.SeqDn = types.Int64Value(int64(device.Seqdn))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceABPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, data, config DeviceABPResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appId := state.AppId.ValueString()
+	devEUI := state.DevEUI.ValueString()
+
+	if !data.Title.Equal(state.Title) || !data.Description.Equal(state.Description) ||
+		!data.DeviceClass.Equal(state.DeviceClass) || !data.LoRaWANVersion.Equal(state.LoRaWANVersion) {
+
+		body := deviceUpdateBody{
+			Title:       data.Title.ValueString(),
+			Description: data.Description.ValueString(),
+			Devclass:    data.DeviceClass.ValueString(),
+			Lorawan:     loraWANVersionBody(data.LoRaWANVersion),
+		}
+
+		opts := loriot.LoRaDevicesApi1NwkAppAPPIDDeviceDEVEUIPostOpts{
+			Body: optional.NewInterface(body),
+		}
+
+		httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIPost(ctx, appId, devEUI, &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update ABP Device", httpResp, err))
+			return
+		}
+	}
+
+	// Changes to the application session key are updated through the appskey
+	// API. The write-only key is only sent when its version changes.
+	if !data.AppSKey.Equal(state.AppSKey) || !data.AppSKeyWOVersion.Equal(state.AppSKeyWOVersion) {
+		opts := loriot.LoRaDevicesApi1NwkAppAPPIDDeviceDEVEUIAppskeyPostOpts{
+			Body: optional.NewInterface(loriot.DeveuiAppskeyBody{
+				Appskey: writeOnlyValue(data.AppSKey, config.AppSKeyWO).ValueString(),
+			}),
+		}
+
+		_, httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIAppskeyPost(ctx, appId, devEUI, &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update AppSKey of ABP Device", httpResp, err))
+			return
+		}
+	}
+
+	// The frame counters can only be changed by resetting them to 0, which
+	// frameCounterResetOnly ensures is the only change planned
+	resetAll := data.ResetFrameCounters.ValueBool()
+
+	if resetAll || !data.SeqNo.Equal(state.SeqNo) {
+		tflog.Info(ctx, fmt.Sprintf("Resetting uplink frame counter of ABP Device with EUI %s", devEUI))
+
+		httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUISeqnoPost(ctx, appId, devEUI)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to reset uplink frame counter of ABP Device", httpResp, err))
+			return
+		}
+	}
+
+	if resetAll || !data.SeqDn.Equal(state.SeqDn) {
+		tflog.Info(ctx, fmt.Sprintf("Resetting downlink frame counter of ABP Device with EUI %s", devEUI))
+
+		httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUISeqdnPost(ctx, appId, devEUI)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to reset downlink frame counter of ABP Device", httpResp, err))
+			return
+		}
+	}
+
+	// Re-read the device to ensure the most up-to-date version is returned
+	device, httpResp, err := r.client.getDevice(ctx, appId, devEUI)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read ABP Device", httpResp, err))
+		return
+	}
+
+	data.readDevice(device)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceABPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeviceABPResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Device may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIDelete(ctx, data.AppId.ValueString(), data.DevEUI.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete ABP Device", httpResp, err))
+		return
+	}
+}
+
+func (r *DeviceABPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importDeviceState(ctx, req, resp)
+}
+
+// readDevice copies the values returned by the API into the model. Session
+// keys are left as configured, as they are not returned by the API, and the
+// frame counters are refreshed by Read.
+func (m *DeviceABPResourceModel) readDevice(device deviceResponse) {
+	if !strings.EqualFold(m.DevEUI.ValueString(), device.Id) {
+		m.DevEUI = types.StringValue(device.Id)
+	}
+
+	if device.Devaddr != "" && !strings.EqualFold(m.DevAddr.ValueString(), device.Devaddr) {
+		m.DevAddr = types.StringValue(device.Devaddr)
+	}
+
+	readDescription(device, m.DevEUI, &m.Title, &m.Description)
+
+	if device.Devclass != "" {
+		m.DeviceClass = types.StringValue(device.Devclass)
+	}
+
+	if device.Lorawan != nil || m.LoRaWANVersion.IsUnknown() {
+		m.LoRaWANVersion = loraWANVersionValue(device.Lorawan)
+	}
+
+	if m.ResetFrameCounters.IsNull() {
+		m.ResetFrameCounters = types.BoolValue(false)
+	}
+}
+
+// frameCounterResetOnly returns a plan modifier which only allows the frame
+// counter of a registered device to be changed to 0, as the API can reset the
+// counters but not set them.
+func frameCounterResetOnly() planmodifier.Int64 {
+	return frameCounterResetOnlyModifier{}
+}
+
+type frameCounterResetOnlyModifier struct{}
+
+func (m frameCounterResetOnlyModifier) Description(ctx context.Context) string {
+	return "Once the device is registered, the frame counter can only be reset to 0."
+}
+
+func (m frameCounterResetOnlyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m frameCounterResetOnlyModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Any counter can be set when the device is created
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) || req.PlanValue.ValueInt64() == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Frame Counter Change",
+		fmt.Sprintf("The frame counter of a registered device cannot be changed from %d to %d, as it can only be reset to 0. "+
+			"Remove the value from the configuration to follow the counter of the network server, or set it to 0 to reset the counter.",
+			req.StateValue.ValueInt64(), req.PlanValue.ValueInt64()),
+	)
+}
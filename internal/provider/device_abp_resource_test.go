@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDeviceABPResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceABPResourceConfig("one", "A"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "deveui", "0011223344556677"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "devaddr", "26011F00"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "title", "one"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "device_class", "A"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "seqno", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_device_abp.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_device_abp.test", "app_id", "deveui"),
+				ImportStateVerifyIdentifierAttribute: "deveui",
+				// The keys are not returned by the API
+				ImportStateVerifyIgnore: []string{"nwkskey", "appskey"},
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceABPResourceConfig("two", "C"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "title", "two"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "device_class", "C"),
+				),
+			},
+			// The frame counters advance with traffic without replacing the device
+			{
+				PreConfig: server.changeDevice(t, "BE010000", "0011223344556677", func(device *mockDevice) {
+					device.Seqno = 42
+					device.Seqdn = 7
+				}),
+				Config: server.providerConfig() + testAccDeviceABPResourceConfig("two", "C"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("loriot_device_abp.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "seqno", "42"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "seqdn", "7"),
+				),
+			},
+			// The counters can only be reset
+			{
+				Config:      server.providerConfig() + testAccDeviceABPResourceCountersConfig(5),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Frame Counter Change`),
+			},
+			// Counter reset and description removal testing
+			{
+				Config: server.providerConfig() + testAccDeviceABPResourceCountersConfig(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "seqno", "0"),
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "seqdn", "7"),
+					resource.TestCheckNoResourceAttr("loriot_device_abp.test", "description"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDeviceABPResource_writeOnly(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceABPResourceWriteOnlyConfig("F0E0D0C0B0A090807060504030201000", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_device_abp.test", "nwkskey"),
+					resource.TestCheckNoResourceAttr("loriot_device_abp.test", "nwkskey_wo"),
+					resource.TestCheckNoResourceAttr("loriot_device_abp.test", "appskey"),
+					resource.TestCheckNoResourceAttr("loriot_device_abp.test", "appskey_wo"),
+					server.testCheckDeviceKeys("BE010000", "0011223344556677", mockDevice{
+						Nwkskey: "000102030405060708090A0B0C0D0E0F",
+						Appskey: "F0E0D0C0B0A090807060504030201000",
+					}),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceABPResourceWriteOnlyConfig("0F0E0D0C0B0A09080706050403020100", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device_abp.test", "appskey_wo_version", "2"),
+					server.testCheckDeviceKeys("BE010000", "0011223344556677", mockDevice{
+						Nwkskey: "000102030405060708090A0B0C0D0E0F",
+						Appskey: "0F0E0D0C0B0A09080706050403020100",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDeviceABPResource_missingAppSKey(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "loriot_device_abp" "test" {
+  app_id  = "BE010000"
+  deveui  = "0011223344556677"
+  devaddr = "26011F00"
+  nwkskey = "000102030405060708090A0B0C0D0E0F"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccDeviceABPResourceConfig(title string, deviceClass string) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device_abp" "test" {
+  app_id       = loriot_app.test.app_id
+  deveui       = "0011223344556677"
+  devaddr      = "26011F00"
+  nwkskey      = "000102030405060708090A0B0C0D0E0F"
+  appskey      = "F0E0D0C0B0A090807060504030201000"
+  title        = %[1]q
+  description  = "Test device"
+  device_class = %[2]q
+}
+`, title, deviceClass)
+}
+
+func testAccDeviceABPResourceCountersConfig(seqno int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device_abp" "test" {
+  app_id       = loriot_app.test.app_id
+  deveui       = "0011223344556677"
+  devaddr      = "26011F00"
+  nwkskey      = "000102030405060708090A0B0C0D0E0F"
+  appskey      = "F0E0D0C0B0A090807060504030201000"
+  title        = "two"
+  device_class = "C"
+  seqno        = %[1]d
+}
+`, seqno)
+}
+
+func testAccDeviceABPResourceWriteOnlyConfig(appSKey string, appSKeyVersion int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device_abp" "test" {
+  app_id             = loriot_app.test.app_id
+  deveui             = "0011223344556677"
+  devaddr            = "26011F00"
+  nwkskey_wo         = "000102030405060708090A0B0C0D0E0F"
+  nwkskey_wo_version = 1
+  appskey_wo         = %[1]q
+  appskey_wo_version = %[2]d
+}
+`, appSKey, appSKeyVersion)
+}
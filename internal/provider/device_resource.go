@@ -0,0 +1,524 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeviceResource{}
+var _ resource.ResourceWithImportState = &DeviceResource{}
+
+func NewDeviceResource() resource.Resource {
+	return &DeviceResource{}
+}
+
+// DeviceResource defines the resource implementation.
+type DeviceResource struct {
+	client *loriotClient
+}
+
+// DeviceResourceModel describes the resource data model.
+type DeviceResourceModel struct {
+	AppId           types.String `tfsdk:"app_id"`
+	DevEUI          types.String `tfsdk:"deveui"`
+	AppEUI          types.String `tfsdk:"appeui"`
+	AppKey          types.String `tfsdk:"appkey"`
+	AppKeyWO        types.String `tfsdk:"appkey_wo"`
+	AppKeyWOVersion types.Int64  `tfsdk:"appkey_wo_version"`
+	NwkKey          types.String `tfsdk:"nwkkey"`
+	NwkKeyWO        types.String `tfsdk:"nwkkey_wo"`
+	NwkKeyWOVersion types.Int64  `tfsdk:"nwkkey_wo_version"`
+	Title           types.String `tfsdk:"title"`
+	Description     types.String `tfsdk:"description"`
+	DeviceClass     types.String `tfsdk:"device_class"`
+	LoRaWANVersion  types.String `tfsdk:"lorawan_version"`
+	DevAddr         types.String `tfsdk:"devaddr"`
+}
+
+// deviceOtaaBody extends the client's OTAA registration body with the
+// LoRaWAN 1.1 network root key, which the generated client does not model.
+type deviceOtaaBody struct {
+	loriot.DevicesOtaaBody
+	Nwkkey string `json:"nwkkey,omitempty"`
+}
+
+// deviceUpdateBody is the body of the device update API. The client's model
+// omits empty fields, which the API leaves unchanged, so the description is
+// always sent to clear it when it is removed.
+type deviceUpdateBody struct {
+	Title       string                                     `json:"title,omitempty"`
+	Description string                                     `json:"description"`
+	Devclass    string                                     `json:"devclass,omitempty"`
+	Lorawan     *loriot.Model1nwkappAppiDdevicesabpLorawan `json:"lorawan,omitempty"`
+}
+
+// deviceResponse extends the client's device model with the title and
+// description, which the generated client does not model.
+type deviceResponse struct {
+	loriot.Device
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "OTAA device resource",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deveui": schema.StringAttribute{
+				MarkdownDescription: "Device EUI in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(16),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"appeui": schema.StringAttribute{
+				MarkdownDescription: "Application EUI (JoinEUI in LoRaWAN 1.1) in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(16),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"appkey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 application key in hexadecimal format. Exactly one of `appkey` or `appkey_wo` must be set",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("appkey_wo")),
+				},
+			},
+			"appkey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 application key in hexadecimal format, which is not stored in the plan or state. It is only sent when the device is created or `appkey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("appkey_wo_version")),
+				},
+			},
+			"appkey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `appkey_wo`, which must be changed to update the application key",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("appkey_wo")),
+				},
+			},
+			"nwkkey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 network key in hexadecimal format, used by LoRaWAN 1.1 devices",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ConflictsWith(path.MatchRoot("nwkkey_wo")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nwkkey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 network key in hexadecimal format, used by LoRaWAN 1.1 devices, which is not stored in the plan or state. It is only sent when the device is created, which it is again when `nwkkey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("nwkkey_wo_version")),
+				},
+			},
+			"nwkkey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `nwkkey_wo`. The network key cannot be updated, so the device is replaced when it changes",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("nwkkey_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Device title, the device EUI by default",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Device description",
+				Optional:            true,
+			},
+			"device_class": schema.StringAttribute{
+				MarkdownDescription: "Device LoRaWAN class type, one of `A`, `B` or `C`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("A"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "B", "C"),
+				},
+			},
+			"lorawan_version": schema.StringAttribute{
+				MarkdownDescription: "LoRaWAN version implemented by the device, as `major.minor` or `major.minor.revision` (for example `1.0.3`)",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(loraWANVersionRegexp, "must be formatted as major.minor or major.minor.revision"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"devaddr": schema.StringAttribute{
+				MarkdownDescription: "Device address assigned by the network on join",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config DeviceResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The title defaults to the device EUI, as it does in the Loriot UI
+	if data.Title.IsUnknown() {
+		data.Title = data.DevEUI
+	}
+
+	body := deviceOtaaBody{
+		DevicesOtaaBody: loriot.DevicesOtaaBody{
+			Title:       data.Title.ValueString(),
+			Description: data.Description.ValueString(),
+			Devclass:    data.DeviceClass.ValueString(),
+			Appkey:      writeOnlyValue(data.AppKey, config.AppKeyWO).ValueString(),
+			Deveui:      data.DevEUI.ValueString(),
+			Appeui:      data.AppEUI.ValueString(),
+			Lorawan:     loraWANVersionBody(data.LoRaWANVersion),
+		},
+		Nwkkey: writeOnlyValue(data.NwkKey, config.NwkKeyWO).ValueString(),
+	}
+
+	opts := loriot.LoRaDevicesApi1NwkAppAPPIDDevicesOtaaPostOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	device, httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDevicesOtaaPost(ctx, data.AppId.ValueString(), &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create Device", httpResp, err))
+		return
+	}
+
+	data.readDevice(deviceResponse{Device: device, Title: body.Title, Description: body.Description})
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeviceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Device with EUI %s in App %s", data.DevEUI.ValueString(), data.AppId.ValueString()))
+
+	device, httpResp, err := r.client.getDevice(ctx, data.AppId.ValueString(), data.DevEUI.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("Device %s no longer exists in App %s, removing from state", data.DevEUI.ValueString(), data.AppId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read Device", httpResp, err))
+		return
+	}
+
+	data.readDevice(device)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, data, config DeviceResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Title.Equal(state.Title) || !data.Description.Equal(state.Description) ||
+		!data.DeviceClass.Equal(state.DeviceClass) || !data.LoRaWANVersion.Equal(state.LoRaWANVersion) {
+
+		body := deviceUpdateBody{
+			Title:       data.Title.ValueString(),
+			Description: data.Description.ValueString(),
+			Devclass:    data.DeviceClass.ValueString(),
+			Lorawan:     loraWANVersionBody(data.LoRaWANVersion),
+		}
+
+		opts := loriot.LoRaDevicesApi1NwkAppAPPIDDeviceDEVEUIPostOpts{
+			Body: optional.NewInterface(body),
+		}
+
+		httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIPost(ctx, state.AppId.ValueString(), state.DevEUI.ValueString(), &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update Device", httpResp, err))
+			return
+		}
+	}
+
+	// Changes to the application key are updated through the appkey API. The
+	// write-only key is only sent when its version changes.
+	if !data.AppKey.Equal(state.AppKey) || !data.AppKeyWOVersion.Equal(state.AppKeyWOVersion) {
+		opts := loriot.LoRaDevicesApi1NwkAppAPPIDDeviceDEVEUIAppkeyPostOpts{
+			Body: optional.NewInterface(loriot.DeveuiAppkeyBody{
+				Appkey: writeOnlyValue(data.AppKey, config.AppKeyWO).ValueString(),
+			}),
+		}
+
+		_, httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIAppkeyPost(ctx, state.AppId.ValueString(), state.DevEUI.ValueString(), &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update AppKey of Device", httpResp, err))
+			return
+		}
+	}
+
+	// Re-read the device to ensure the most up-to-date version is returned
+	device, httpResp, err := r.client.getDevice(ctx, state.AppId.ValueString(), state.DevEUI.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read Device", httpResp, err))
+		return
+	}
+
+	data.readDevice(device)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeviceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Device may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIDelete(ctx, data.AppId.ValueString(), data.DevEUI.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete Device", httpResp, err))
+		return
+	}
+}
+
+func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importDeviceState(ctx, req, resp)
+}
+
+// readDevice copies the values returned by the API into the model. Keys are
+// not returned by the API, so are left as configured.
+func (m *DeviceResourceModel) readDevice(device deviceResponse) {
+	if !strings.EqualFold(m.DevEUI.ValueString(), device.Id) {
+		m.DevEUI = types.StringValue(device.Id)
+	}
+
+	if device.Appeui != "" && !strings.EqualFold(m.AppEUI.ValueString(), device.Appeui) {
+		m.AppEUI = types.StringValue(device.Appeui)
+	}
+
+	readDescription(device, m.DevEUI, &m.Title, &m.Description)
+
+	if device.Devclass != "" {
+		m.DeviceClass = types.StringValue(device.Devclass)
+	}
+
+	if device.Lorawan != nil || m.LoRaWANVersion.IsUnknown() {
+		m.LoRaWANVersion = loraWANVersionValue(device.Lorawan)
+	}
+
+	m.DevAddr = types.StringValue(device.Devaddr)
+}
+
+// getDevice reads a device, including the title and description which the
+// generated client drops.
+func (c *loriotClient) getDevice(ctx context.Context, appId string, devEUI string) (deviceResponse, *http.Response, error) {
+	var device deviceResponse
+
+	httpResp, err := c.do(ctx, http.MethodGet, "/1/nwk/app/"+url.PathEscape(appId)+"/device/"+url.PathEscape(devEUI), nil, &device)
+
+	return device, httpResp, err
+}
+
+// readDescription copies the title and description of the device into the
+// title and description of a device model. The title falls back to the device
+// EUI, and an unset description is left null.
+func readDescription(device deviceResponse, devEUI types.String, title *types.String, description *types.String) {
+	switch {
+	case device.Title != "":
+		*title = types.StringValue(device.Title)
+	case title.IsNull() || title.IsUnknown():
+		*title = devEUI
+	}
+
+	if device.Description != "" || !description.IsNull() {
+		*description = types.StringValue(device.Description)
+	}
+}
+
+// importDeviceState imports a device identified by "app_id/deveui".
+func importDeviceState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/deveui. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deveui"), idParts[1])...)
+}
+
+var loraWANVersionRegexp = regexp.MustCompile(`^\d+\.\d+(\.[0-9A-Za-z]+)?$`)
+
+// hexStringValidator validates that a string is exactly length hexadecimal
+// characters.
+func hexStringValidator(length int) validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile(fmt.Sprintf("^[0-9A-Fa-f]{%d}$", length)),
+		fmt.Sprintf("must be %d hexadecimal characters", length),
+	)
+}
+
+// loraWANVersionBody converts a "major.minor[.revision]" string into the
+// structure expected by the device APIs.
+func loraWANVersionBody(version types.String) *loriot.Model1nwkappAppiDdevicesabpLorawan {
+	if version.IsNull() || version.IsUnknown() {
+		return nil
+	}
+
+	parts := strings.SplitN(version.ValueString(), ".", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+
+	major, _ := strconv.ParseFloat(parts[0], 64)
+	minor, _ := strconv.ParseFloat(parts[1], 64)
+
+	lorawan := loriot.Model1nwkappAppiDdevicesabpLorawan{
+		Major: major,
+		Minor: minor,
+	}
+
+	if len(parts) == 3 {
+		lorawan.Revision = parts[2]
+	}
+
+	return &lorawan
+}
+
+// loraWANVersionValue converts the LoRaWAN version returned by the device APIs
+// into a "major.minor[.revision]" string.
+func loraWANVersionValue(lorawan *loriot.DeviceProfileDeviceListedLorawan) types.String {
+	if lorawan == nil {
+		return types.StringNull()
+	}
+
+	version := fmt.Sprintf("%d.%d", int64(lorawan.Major), int64(lorawan.Minor))
+	if lorawan.Revision != "" {
+		version += "." + lorawan.Revision
+	}
+
+	return types.StringValue(version)
+}
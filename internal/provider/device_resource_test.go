@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDeviceResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceResourceConfig("one", "A", "000102030405060708090A0B0C0D0E0F"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device.test", "deveui", "0011223344556677"),
+					resource.TestCheckResourceAttr("loriot_device.test", "title", "one"),
+					resource.TestCheckResourceAttr("loriot_device.test", "device_class", "A"),
+					resource.TestCheckResourceAttr("loriot_device.test", "lorawan_version", "1.0.2"),
+					resource.TestCheckResourceAttrSet("loriot_device.test", "devaddr"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_device.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_device.test", "app_id", "deveui"),
+				ImportStateVerifyIdentifierAttribute: "deveui",
+				// The keys are not returned by the API
+				ImportStateVerifyIgnore: []string{"appkey"},
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceResourceConfig("two", "C", "F0E0D0C0B0A090807060504030201000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device.test", "title", "two"),
+					resource.TestCheckResourceAttr("loriot_device.test", "device_class", "C"),
+					resource.TestCheckResourceAttr("loriot_device.test", "appkey", "F0E0D0C0B0A090807060504030201000"),
+				),
+			},
+			// Description removal testing
+			{
+				Config: server.providerConfig() + `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device" "test" {
+  app_id       = loriot_app.test.app_id
+  deveui       = "0011223344556677"
+  appeui       = "70B3D57ED0000000"
+  appkey       = "F0E0D0C0B0A090807060504030201000"
+  title        = "two"
+  device_class = "C"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_device.test", "description"),
+				),
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					if _, err := server.client().LoRaDevicesApi.V1NwkAppAPPIDDeviceDEVEUIDelete(context.Background(), "BE010000", "0011223344556677"); err != nil {
+						t.Fatalf("unable to delete Device: %s", err)
+					}
+				},
+				Config:             server.providerConfig() + testAccDeviceResourceConfig("two", "C", "F0E0D0C0B0A090807060504030201000"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDeviceResource_writeOnly(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceResourceWriteOnlyConfig("000102030405060708090A0B0C0D0E0F", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_device.test", "appkey"),
+					resource.TestCheckNoResourceAttr("loriot_device.test", "appkey_wo"),
+					resource.TestCheckResourceAttr("loriot_device.test", "appkey_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("loriot_device.test", "nwkkey_wo"),
+					server.testCheckDeviceKeys("BE010000", "0011223344556677", mockDevice{
+						Appkey: "000102030405060708090A0B0C0D0E0F",
+						Nwkkey: "0F0E0D0C0B0A09080706050403020100",
+					}),
+				),
+			},
+			// The key is not updated until its version changes
+			{
+				Config: server.providerConfig() + testAccDeviceResourceWriteOnlyConfig("F0E0D0C0B0A090807060504030201000", 1),
+				Check: server.testCheckDeviceKeys("BE010000", "0011223344556677", mockDevice{
+					Appkey: "000102030405060708090A0B0C0D0E0F",
+					Nwkkey: "0F0E0D0C0B0A09080706050403020100",
+				}),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccDeviceResourceWriteOnlyConfig("F0E0D0C0B0A090807060504030201000", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_device.test", "appkey_wo_version", "2"),
+					server.testCheckDeviceKeys("BE010000", "0011223344556677", mockDevice{
+						Appkey: "F0E0D0C0B0A090807060504030201000",
+						Nwkkey: "0F0E0D0C0B0A09080706050403020100",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccDeviceResourceConfig(title string, deviceClass string, appKey string) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device" "test" {
+  app_id       = loriot_app.test.app_id
+  deveui       = "0011223344556677"
+  appeui       = "70B3D57ED0000000"
+  appkey       = %[3]q
+  title        = %[1]q
+  description  = "Test device"
+  device_class = %[2]q
+}
+`, title, deviceClass, appKey)
+}
+
+func testAccDeviceResourceWriteOnlyConfig(appKey string, appKeyVersion int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device" "test" {
+  app_id            = loriot_app.test.app_id
+  deveui            = "0011223344556677"
+  appeui            = "70B3D57ED0000000"
+  appkey_wo         = %[1]q
+  appkey_wo_version = %[2]d
+  nwkkey_wo         = "0F0E0D0C0B0A09080706050403020100"
+  nwkkey_wo_version = 1
+  lorawan_version   = "1.1.0"
+}
+`, appKey, appKeyVersion)
+}
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &DevicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &DevicesDataSource{}
+)
+
+// devicesPageSize is the number of devices requested per page.
+const devicesPageSize = 100
+
+// devicesListResponse is the response of the device list API. The client's
+// model drops the device titles, so the devices are decoded as deviceResponse.
+type devicesListResponse struct {
+	Page    float64          `json:"page,omitempty"`
+	PerPage float64          `json:"perPage,omitempty"`
+	Total   float64          `json:"total,omitempty"`
+	Devices []deviceResponse `json:"devices,omitempty"`
+}
+
+func NewDevicesDataSource() datasource.DataSource {
+	return &DevicesDataSource{}
+}
+
+// DevicesDataSource defines the data source implementation.
+type DevicesDataSource struct {
+	client *loriotClient
+}
+
+// DevicesDataSourceModel describes the data source data model.
+type DevicesDataSourceModel struct {
+	AppId          types.String                   `tfsdk:"app_id"`
+	TitleContains  types.String                   `tfsdk:"title_contains"`
+	DeviceClass    types.String                   `tfsdk:"device_class"`
+	LastSeenBefore types.String                   `tfsdk:"last_seen_before"`
+	LastSeenAfter  types.String                   `tfsdk:"last_seen_after"`
+	Devices        []DevicesDataSourceDeviceModel `tfsdk:"devices"`
+}
+
+type DevicesDataSourceDeviceModel struct {
+	DevEUI      types.String  `tfsdk:"deveui"`
+	Title       types.String  `tfsdk:"title"`
+	DevAddr     types.String  `tfsdk:"devaddr"`
+	DeviceClass types.String  `tfsdk:"device_class"`
+	RSSI        types.Float64 `tfsdk:"rssi"`
+	SNR         types.Float64 `tfsdk:"snr"`
+	LastSeen    types.String  `tfsdk:"last_seen"`
+	LastJoin    types.String  `tfsdk:"last_join"`
+}
+
+func (d *DevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devices"
+}
+
+func (d *DevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source listing the devices registered with an application",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+			},
+			"title_contains": schema.StringAttribute{
+				MarkdownDescription: "Text the device title must contain. The network server matches it ignoring case, and any regular expression characters literally",
+				Optional:            true,
+			},
+			"device_class": schema.StringAttribute{
+				MarkdownDescription: "LoRaWAN device class the devices must have",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "B", "C"),
+				},
+			},
+			"last_seen_before": schema.StringAttribute{
+				MarkdownDescription: "Only include devices last seen before this RFC 3339 timestamp",
+				Optional:            true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"last_seen_after": schema.StringAttribute{
+				MarkdownDescription: "Only include devices last seen after this RFC 3339 timestamp",
+				Optional:            true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "Devices matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"deveui": schema.StringAttribute{
+							MarkdownDescription: "Device EUI in hexadecimal format",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "Device title",
+							Computed:            true,
+						},
+						"devaddr": schema.StringAttribute{
+							MarkdownDescription: "Device address in hexadecimal format",
+							Computed:            true,
+						},
+						"device_class": schema.StringAttribute{
+							MarkdownDescription: "LoRaWAN device class",
+							Computed:            true,
+						},
+						"rssi": schema.Float64Attribute{
+							MarkdownDescription: "Received Signal Strength Indicator of the last frame",
+							Computed:            true,
+						},
+						"snr": schema.Float64Attribute{
+							MarkdownDescription: "Signal to Noise Ratio of the last frame",
+							Computed:            true,
+						},
+						"last_seen": schema.StringAttribute{
+							MarkdownDescription: "Date the device was last seen",
+							Computed:            true,
+						},
+						"last_join": schema.StringAttribute{
+							MarkdownDescription: "Date of the last successful join",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DevicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The timestamps have already been checked by the validator
+	var lastSeenBefore, lastSeenAfter time.Time
+	if !data.LastSeenBefore.IsNull() {
+		lastSeenBefore, _ = time.Parse(time.RFC3339, data.LastSeenBefore.ValueString())
+	}
+
+	if !data.LastSeenAfter.IsNull() {
+		lastSeenAfter, _ = time.Parse(time.RFC3339, data.LastSeenAfter.ValueString())
+	}
+
+	data.Devices = []DevicesDataSourceDeviceModel{}
+
+	for page := float64(1); ; page++ {
+		tflog.Info(ctx, fmt.Sprintf("Fetching page %.0f of Devices for App %s", page, data.AppId.ValueString()))
+
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(int(page)))
+		query.Set("perPage", strconv.Itoa(devicesPageSize))
+
+		// The title filter is applied by the network server to avoid listing
+		// every device. It matches a regular expression, so the text is
+		// quoted to be matched literally.
+		if !data.TitleContains.IsNull() {
+			query.Set("filter", "title~"+regexp.QuoteMeta(data.TitleContains.ValueString()))
+		}
+
+		var result devicesListResponse
+
+		httpResp, err := d.client.do(ctx, http.MethodGet, "/1/nwk/app/"+url.PathEscape(data.AppId.ValueString())+"/devices?"+query.Encode(), nil, &result)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to list Devices", httpResp, err))
+			return
+		}
+
+		if len(result.Devices) == 0 {
+			break
+		}
+
+		for _, device := range result.Devices {
+			if !data.DeviceClass.IsNull() && device.Devclass != data.DeviceClass.ValueString() {
+				continue
+			}
+
+			if !data.LastSeenBefore.IsNull() || !data.LastSeenAfter.IsNull() {
+				lastSeen, err := time.Parse(time.RFC3339, device.LastSeen)
+				if err != nil {
+					// Devices which have never been seen cannot match
+					continue
+				}
+
+				if !data.LastSeenBefore.IsNull() && !lastSeen.Before(lastSeenBefore) {
+					continue
+				}
+
+				if !data.LastSeenAfter.IsNull() && !lastSeen.After(lastSeenAfter) {
+					continue
+				}
+			}
+
+			data.Devices = append(data.Devices, DevicesDataSourceDeviceModel{
+				DevEUI:      types.StringValue(device.Id),
+				Title:       types.StringValue(device.Title),
+				DevAddr:     types.StringValue(device.Devaddr),
+				DeviceClass: types.StringValue(device.Devclass),
+				RSSI:        types.Float64Value(device.Rssi),
+				SNR:         types.Float64Value(device.Snr),
+				LastSeen:    types.StringValue(device.LastSeen),
+				LastJoin:    types.StringValue(device.LastJoin),
+			})
+		}
+
+		if page*devicesPageSize >= result.Total {
+			break
+		}
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// timestampValidator validates that a string is an RFC 3339 timestamp.
+type timestampValidator struct{}
+
+func (v timestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("The value %q is not a valid RFC 3339 timestamp: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
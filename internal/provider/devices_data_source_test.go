@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDevicesDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccDevicesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_devices.all", "devices.#", "2"),
+					resource.TestCheckResourceAttr("data.loriot_devices.class_c", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.loriot_devices.class_c", "devices.0.deveui", "0011223344556688"),
+					resource.TestCheckResourceAttr("data.loriot_devices.title", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.loriot_devices.title", "devices.0.deveui", "0011223344556677"),
+					resource.TestCheckResourceAttr("data.loriot_devices.title", "devices.0.title", "Water Meter"),
+					resource.TestCheckResourceAttr("data.loriot_devices.literal", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.loriot_devices.literal", "devices.0.deveui", "0011223344556688"),
+				),
+			},
+			// Last seen testing, with the timestamps in the format Loriot returns
+			{
+				PreConfig: server.changeDevice(t, "BE010000", "0011223344556677", func(device *mockDevice) {
+					device.LastSeen = "2024-03-01T10:15:30.123Z"
+				}),
+				Config: server.providerConfig() + testAccDevicesDataSourceConfig + `
+data "loriot_devices" "seen" {
+  app_id          = loriot_app.test.app_id
+  last_seen_after = "2024-03-01T10:15:30Z"
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+
+data "loriot_devices" "not_seen" {
+  app_id           = loriot_app.test.app_id
+  last_seen_before = "2024-03-01T10:15:30Z"
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_devices.seen", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.loriot_devices.seen", "devices.0.deveui", "0011223344556677"),
+					resource.TestCheckResourceAttr("data.loriot_devices.seen", "devices.0.last_seen", "2024-03-01T10:15:30.123Z"),
+					resource.TestCheckResourceAttr("data.loriot_devices.not_seen", "devices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDevicesDataSourceConfig = `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+resource "loriot_device" "meter" {
+  app_id = loriot_app.test.app_id
+  deveui = "0011223344556677"
+  appeui = "70B3D57ED0000000"
+  appkey = "000102030405060708090A0B0C0D0E0F"
+  title  = "Water Meter"
+}
+
+resource "loriot_device" "valve" {
+  app_id       = loriot_app.test.app_id
+  deveui       = "0011223344556688"
+  appeui       = "70B3D57ED0000000"
+  appkey       = "000102030405060708090A0B0C0D0E0F"
+  title        = "Valve (1.5)"
+  device_class = "C"
+}
+
+data "loriot_devices" "all" {
+  app_id = loriot_app.test.app_id
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+
+data "loriot_devices" "class_c" {
+  app_id       = loriot_app.test.app_id
+  device_class = "C"
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+
+data "loriot_devices" "title" {
+  app_id         = loriot_app.test.app_id
+  title_contains = "meter"
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+
+data "loriot_devices" "literal" {
+  app_id         = loriot_app.test.app_id
+  title_contains = "(1.5"
+
+  depends_on = [loriot_device.meter, loriot_device.valve]
+}
+`
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// loriotRegions are the public Loriot network server clusters, each of which
+// is served from https://<region>.loriot.io.
+var loriotRegions = []string{"eu1", "eu2", "eu3", "eu5", "us1", "us2", "ap1", "ap2", "ap3", "au1", "as1", "ca1"}
+
+// loriotAPIPath is the path prefix of the API, which the client adds to each
+// request.
+const loriotAPIPath = "/1"
+
+// regionURL returns the base URL of a public Loriot cluster.
+func regionURL(region string) string {
+	return fmt.Sprintf("https://%s.loriot.io", region)
+}
+
+// normalizeHost returns the base URL of the API at host. A bare hostname is
+// assumed to be served over https. The API path may be included, but is
+// removed as the client adds it to each request. Plain http is only accepted
+// for loopback addresses, for local development.
+func normalizeHost(host string) (string, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Host == "" {
+		return "", errors.New("missing hostname")
+	}
+
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", errors.New("must not include credentials, a query or a fragment")
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !isLoopback(u.Hostname()) {
+			return "", errors.New("must use https, plain http is only allowed for localhost")
+		}
+	default:
+		return "", fmt.Errorf("unsupported scheme %q, must use https", u.Scheme)
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	path = strings.TrimSuffix(path, loriotAPIPath)
+
+	if path != "" {
+		return "", fmt.Errorf("unexpected path %q, the API is served from %s", u.Path, loriotAPIPath)
+	}
+
+	return u.Scheme + "://" + u.Host, nil
+}
+
+func isLoopback(hostname string) bool {
+	if hostname == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(hostname)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// newHTTPTransport returns the transport used to connect to the Loriot
+// instance, trusting the certificates in caBundleFile in addition to the
+// system roots, or skipping verification entirely when insecure is set.
+func newHTTPTransport(caBundleFile string, insecure bool) (http.RoundTripper, error) {
+	if caBundleFile == "" && !insecure {
+		return http.DefaultTransport, nil
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport %T", http.DefaultTransport)
+	}
+
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Only used for private instances with self-signed certificates
+		InsecureSkipVerify: insecure,
+	}
+
+	if caBundleFile != "" {
+		pem, err := os.ReadFile(caBundleFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", caBundleFile)
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return transport, nil
+}
+
+// hostValidator checks a value is a URL accepted by normalizeHost.
+type hostValidator struct{}
+
+func (v hostValidator) Description(ctx context.Context) string {
+	return "value must be an https URL or hostname of a Loriot instance"
+}
+
+func (v hostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := normalizeHost(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Loriot instance Host",
+			fmt.Sprintf("The value %q is not a valid Loriot instance host: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
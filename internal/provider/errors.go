@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+)
+
+// requestIdHeader is the response header carrying the identifier Loriot
+// assigns to each request, which support needs to trace a failure.
+const requestIdHeader = "X-Request-Id"
+
+// apiError holds the details of a failed request to the Loriot API.
+type apiError struct {
+	StatusCode int
+	Status     string
+	Code       string
+	Message    string
+	RequestId  string
+}
+
+// apiErrorBody is the JSON body returned by Loriot when a request fails.
+type apiErrorBody struct {
+	Error     string          `json:"error"`
+	Message   string          `json:"message"`
+	Code      json.RawMessage `json:"code"`
+	RequestId string          `json:"requestId"`
+}
+
+// responseError is returned for a failed request made without the API
+// client, carrying the same details as the errors the client returns.
+type responseError struct {
+	status string
+	body   []byte
+}
+
+func (e responseError) Error() string {
+	return e.status
+}
+
+// parseAPIError unwraps an error returned by the API client. It returns false
+// if the request did not reach the API, such as when the connection failed.
+func parseAPIError(httpResp *http.Response, err error) (apiError, bool) {
+	var status string
+	var body []byte
+
+	var swaggerErr loriot.GenericSwaggerError
+	var respErr responseError
+
+	switch {
+	case errors.As(err, &swaggerErr):
+		// The client uses the response status as the error message
+		status, body = swaggerErr.Error(), swaggerErr.Body()
+	case errors.As(err, &respErr):
+		status, body = respErr.status, respErr.body
+	default:
+		return apiError{}, false
+	}
+
+	result := apiError{Status: status}
+
+	if httpResp != nil {
+		result.StatusCode = httpResp.StatusCode
+		result.Status = httpResp.Status
+		result.RequestId = httpResp.Header.Get(requestIdHeader)
+	} else if code, _, found := strings.Cut(result.Status, " "); found {
+		result.StatusCode, _ = strconv.Atoi(code)
+	}
+
+	var errBody apiErrorBody
+	if jsonErr := json.Unmarshal(body, &errBody); jsonErr == nil {
+		result.Message = errBody.Error
+		if result.Message == "" {
+			result.Message = errBody.Message
+		}
+
+		result.Code = strings.Trim(string(errBody.Code), `"`)
+
+		if result.RequestId == "" {
+			result.RequestId = errBody.RequestId
+		}
+	} else if text := strings.TrimSpace(string(body)); text != "" {
+		result.Message = text
+	}
+
+	return result, true
+}
+
+// clientError returns the summary and detail of a diagnostic for an error
+// returned by the API client, where action describes what failed, such as
+// "Unable to read App".
+//
+//	resp.Diagnostics.AddError(clientError("Unable to read App", httpResp, err))
+func clientError(action string, httpResp *http.Response, err error) (string, string) {
+	apiErr, ok := parseAPIError(httpResp, err)
+	if !ok {
+		return "Client Error", fmt.Sprintf("%s, got error: %s", action, err)
+	}
+
+	var summary, hint string
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		summary = "Authentication Failed"
+		hint = "The credentials were rejected. Check the API key or application token configured on the provider are correct and have not expired or been revoked."
+	case http.StatusForbidden:
+		summary = "Permission Denied"
+		hint = "The credentials are valid but are not allowed to perform this operation. Check the permissions of the user or organization the credentials belong to."
+	case http.StatusNotFound:
+		summary = "Resource Not Found"
+		hint = "The resource does not exist. It may have been deleted outside of Terraform, or the identifier may be wrong."
+	case http.StatusConflict:
+		summary = "Resource Conflict"
+		hint = "The resource already exists or conflicts with an existing resource. Import the existing resource or choose a different identifier."
+	case http.StatusUnprocessableEntity:
+		summary = "Invalid Request"
+		hint = "The request was rejected by the API. Check the configured values are valid for the Loriot instance, and within the limits of the account."
+	default:
+		summary = "Client Error"
+	}
+
+	var detail strings.Builder
+
+	fmt.Fprintf(&detail, "%s, got error: %s", action, apiErr.Status)
+
+	if apiErr.Message != "" {
+		fmt.Fprintf(&detail, "\n\nLoriot error: %s", apiErr.Message)
+	}
+
+	if apiErr.Code != "" {
+		fmt.Fprintf(&detail, "\nLoriot error code: %s", apiErr.Code)
+	}
+
+	if apiErr.RequestId != "" {
+		fmt.Fprintf(&detail, "\nRequest ID: %s", apiErr.RequestId)
+	}
+
+	if hint != "" {
+		fmt.Fprintf(&detail, "\n\n%s", hint)
+	}
+
+	return summary, detail.String()
+}
+
+// isNotFound reports whether a request failed because the object it refers
+// to does not exist, such as when it was deleted outside of Terraform.
+func isNotFound(httpResp *http.Response, err error) bool {
+	apiErr, ok := parseAPIError(httpResp, err)
+
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
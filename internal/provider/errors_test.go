@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+)
+
+func TestClientError(t *testing.T) {
+	testCases := map[string]struct {
+		status      int
+		body        string
+		requestId   string
+		wantSummary string
+		wantDetail  []string
+	}{
+		"unauthorized": {
+			status:      http.StatusUnauthorized,
+			body:        `{"error":"Invalid API key","code":401}`,
+			wantSummary: "Authentication Failed",
+			wantDetail:  []string{"Unable to read App, got error: 401 Unauthorized", "Loriot error: Invalid API key", "Loriot error code: 401", "credentials were rejected"},
+		},
+		"forbidden": {
+			status:      http.StatusForbidden,
+			body:        `{"message":"Access denied","code":"ACCESS_DENIED"}`,
+			wantSummary: "Permission Denied",
+			wantDetail:  []string{"Loriot error: Access denied", "Loriot error code: ACCESS_DENIED"},
+		},
+		"not-found": {
+			status:      http.StatusNotFound,
+			body:        `{"error":"Application not found","code":404}`,
+			requestId:   "5f0c7d2a",
+			wantSummary: "Resource Not Found",
+			wantDetail:  []string{"Loriot error: Application not found", "Request ID: 5f0c7d2a", "deleted outside of Terraform"},
+		},
+		"conflict": {
+			status:      http.StatusConflict,
+			body:        `{"error":"Device already exists","code":409,"requestId":"a1b2c3"}`,
+			wantSummary: "Resource Conflict",
+			wantDetail:  []string{"Loriot error: Device already exists", "Request ID: a1b2c3"},
+		},
+		"unprocessable": {
+			status:      http.StatusUnprocessableEntity,
+			body:        `{"error":"Device limit reached","code":422}`,
+			wantSummary: "Invalid Request",
+			wantDetail:  []string{"Loriot error: Device limit reached"},
+		},
+		"server-error": {
+			status:      http.StatusInternalServerError,
+			body:        "upstream failure",
+			wantSummary: "Client Error",
+			wantDetail:  []string{"500 Internal Server Error", "Loriot error: upstream failure"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if testCase.requestId != "" {
+					w.Header().Set(requestIdHeader, testCase.requestId)
+				}
+
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			cfg := loriot.NewConfiguration()
+			cfg.BasePath = server.URL
+			client := loriot.NewAPIClient(cfg)
+
+			_, httpResp, err := client.LoRaApplicationApi.V1NwkAppAPPIDGet(context.Background(), "BE010000")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			summary, detail := clientError("Unable to read App", httpResp, err)
+
+			if summary != testCase.wantSummary {
+				t.Errorf("got summary %q, want %q", summary, testCase.wantSummary)
+			}
+
+			for _, want := range testCase.wantDetail {
+				if !strings.Contains(detail, want) {
+					t.Errorf("detail %q does not contain %q", detail, want)
+				}
+			}
+
+			if got, want := isNotFound(httpResp, err), testCase.status == http.StatusNotFound; got != want {
+				t.Errorf("got isNotFound %t, want %t", got, want)
+			}
+
+			// The status is also recovered without the response
+			if summary, _ := clientError("Unable to read App", nil, err); summary != testCase.wantSummary {
+				t.Errorf("got summary %q without response, want %q", summary, testCase.wantSummary)
+			}
+		})
+	}
+}
+
+func TestClientErrorResponse(t *testing.T) {
+	err := responseError{
+		status: "400 Bad Request",
+		body:   []byte(`{"error":"invalid multicast device limit","code":400}`),
+	}
+
+	summary, detail := clientError("Unable to update multicast device limit of App", nil, err)
+
+	if summary != "Client Error" {
+		t.Errorf("got summary %q, want %q", summary, "Client Error")
+	}
+
+	for _, want := range []string{"got error: 400 Bad Request", "Loriot error: invalid multicast device limit", "Loriot error code: 400"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("detail %q does not contain %q", detail, want)
+		}
+	}
+}
+
+func TestClientErrorConnection(t *testing.T) {
+	summary, detail := clientError("Unable to read App", nil, errors.New("connection refused"))
+
+	if summary != "Client Error" {
+		t.Errorf("got summary %q, want %q", summary, "Client Error")
+	}
+
+	if want := "Unable to read App, got error: connection refused"; detail != want {
+		t.Errorf("got detail %q, want %q", detail, want)
+	}
+
+	if isNotFound(nil, errors.New("connection refused")) {
+		t.Error("connection failure reported as not found")
+	}
+}
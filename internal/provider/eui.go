@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// euiSeparators are the characters used to group the bytes of an EUI, which
+// are removed before it is parsed. For example, 00-11-22-33-44-55-66-77 or
+// 0011:2233:4455:6677.
+const euiSeparators = ":-. _"
+
+// parseHexID parses an identifier of length hexadecimal characters, such as
+// an EUI (16) or an application ID (8), ignoring case and any separators.
+func parseHexID(value string, length int) (uint64, error) {
+	digits := stripSeparators(value)
+
+	if len(digits) != length {
+		return 0, fmt.Errorf("%q must be %d hexadecimal characters, excluding separators", value, length)
+	}
+
+	id, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q must only contain hexadecimal characters and separators", value)
+	}
+
+	return id, nil
+}
+
+// stripSeparators removes the separators and any 0x prefix from a
+// hexadecimal value.
+func stripSeparators(value string) string {
+	digits := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(euiSeparators, r) {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	return strings.TrimPrefix(strings.TrimPrefix(digits, "0x"), "0X")
+}
+
+// formatEUI returns the canonical form of an EUI, as used by Loriot, which is
+// 16 uppercase hexadecimal characters.
+func formatEUI(eui uint64) string {
+	return fmt.Sprintf("%016X", eui)
+}
+
+// decimalToUint64 converts a Terraform number to an unsigned 64-bit integer,
+// failing if it is fractional or out of range.
+func decimalToUint64(value *big.Float) (uint64, error) {
+	if !value.IsInt() {
+		return 0, fmt.Errorf("%s must be a whole number", value.Text('f', -1))
+	}
+
+	result, accuracy := value.Uint64()
+	if accuracy != big.Exact {
+		return 0, fmt.Errorf("%s must be between 0 and %d", value.Text('f', -1), uint64(math.MaxUint64))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = EUINormalizeFunction{}
+)
+
+func NewEUINormalizeFunction() function.Function {
+	return EUINormalizeFunction{}
+}
+
+type EUINormalizeFunction struct{}
+
+func (r EUINormalizeFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "eui_normalize"
+}
+
+func (r EUINormalizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize an EUI",
+		MarkdownDescription: "Converts an EUI to the canonical form used by Loriot, 16 uppercase hexadecimal characters. The EUI may be in any case, and the bytes may be separated by `:`, `-`, `.`, `_` or spaces, for example `70-b3-d5-7e-d0-00-00-00`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "eui",
+				MarkdownDescription: "EUI in hexadecimal format",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r EUINormalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var eui string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &eui))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := parseHexID(eui, 16)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid EUI: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, formatEUI(value)))
+}
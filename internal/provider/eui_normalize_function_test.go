@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
-func TestExampleFunction_Known(t *testing.T) {
+func TestEUINormalizeFunction_Known(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
 			tfversion.SkipBelow(tfversion.Version1_8_0),
@@ -20,19 +20,29 @@ func TestExampleFunction_Known(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: `
-				output "test" {
-					value = provider::loriot::example("testvalue")
+				output "colons" {
+					value = provider::loriot::eui_normalize("70:b3:d5:7e:d0:00:00:00")
+				}
+
+				output "dashes" {
+					value = provider::loriot::eui_normalize("70-B3-D5-7E-D0-00-00-00")
+				}
+
+				output "plain" {
+					value = provider::loriot::eui_normalize("70b3d57ed0000000")
 				}
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckOutput("test", "testvalue"),
+					resource.TestCheckOutput("colons", "70B3D57ED0000000"),
+					resource.TestCheckOutput("dashes", "70B3D57ED0000000"),
+					resource.TestCheckOutput("plain", "70B3D57ED0000000"),
 				),
 			},
 		},
 	})
 }
 
-func TestExampleFunction_Null(t *testing.T) {
+func TestEUINormalizeFunction_Invalid(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
 			tfversion.SkipBelow(tfversion.Version1_8_0),
@@ -42,17 +52,16 @@ func TestExampleFunction_Null(t *testing.T) {
 			{
 				Config: `
 				output "test" {
-					value = provider::loriot::example(null)
+					value = provider::loriot::eui_normalize("70:b3:d5:7e:d0")
 				}
 				`,
-				// The parameter does not enable AllowNullValue
-				ExpectError: regexp.MustCompile(`argument must not be null`),
+				ExpectError: regexp.MustCompile(`must\s+be\s+16\s+hexadecimal\s+characters`),
 			},
 		},
 	})
 }
 
-func TestExampleFunction_Unknown(t *testing.T) {
+func TestEUINormalizeFunction_Null(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
 			tfversion.SkipBelow(tfversion.Version1_8_0),
@@ -61,17 +70,12 @@ func TestExampleFunction_Unknown(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: `
-				resource "terraform_data" "test" {
-					input = "testvalue"
-				}
-				
 				output "test" {
-					value = provider::loriot::example(terraform_data.test.output)
+					value = provider::loriot::eui_normalize(null)
 				}
 				`,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckOutput("test", "testvalue"),
-				),
+				// The parameter does not enable AllowNullValue
+				ExpectError: regexp.MustCompile(`argument\s+must\s+not\s+be\s+null`),
 			},
 		},
 	})
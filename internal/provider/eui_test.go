@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseHexID(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		length  int
+		want    uint64
+		wantErr string
+	}{
+		"plain":        {value: "70B3D57ED0000000", length: 16, want: 0x70B3D57ED0000000},
+		"lowercase":    {value: "70b3d57ed0000000", length: 16, want: 0x70B3D57ED0000000},
+		"colons":       {value: "70:b3:d5:7e:d0:00:00:00", length: 16, want: 0x70B3D57ED0000000},
+		"dashes":       {value: "70-B3-D5-7E-D0-00-00-00", length: 16, want: 0x70B3D57ED0000000},
+		"groups":       {value: "70B3.D57E.D000.0000", length: 16, want: 0x70B3D57ED0000000},
+		"spaces":       {value: "70 B3 D5 7E D0 00 00 00", length: 16, want: 0x70B3D57ED0000000},
+		"prefix":       {value: "0x70B3D57ED0000000", length: 16, want: 0x70B3D57ED0000000},
+		"max":          {value: "FFFFFFFFFFFFFFFF", length: 16, want: 0xFFFFFFFFFFFFFFFF},
+		"app-id":       {value: "BE010000", length: 8, want: 0xBE010000},
+		"short":        {value: "70B3D57ED00000", length: 16, wantErr: "must be 16 hexadecimal characters"},
+		"long":         {value: "70B3D57ED000000000", length: 16, wantErr: "must be 16 hexadecimal characters"},
+		"not-hex":      {value: "70B3D57ED000000G", length: 16, wantErr: "must only contain hexadecimal characters"},
+		"sign":         {value: "+0B3D57ED0000000", length: 16, wantErr: "must only contain hexadecimal characters"},
+		"app-id-eui":   {value: "70B3D57ED0000000", length: 8, wantErr: "must be 8 hexadecimal characters"},
+		"empty":        {value: "", length: 16, wantErr: "must be 16 hexadecimal characters"},
+		"only-symbols": {value: "::::", length: 8, wantErr: "must be 8 hexadecimal characters"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseHexID(testCase.value, testCase.length)
+
+			if testCase.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+					t.Errorf("got error %v, want %q", err, testCase.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %X, want %X", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestFormatEUI(t *testing.T) {
+	if got, want := formatEUI(0x70B3D57ED0000000), "70B3D57ED0000000"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, want := formatEUI(0x100), "0000000000000100"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDecimalToUint64(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		want    uint64
+		wantErr string
+	}{
+		"zero":       {value: "0", want: 0},
+		"eui":        {value: "8121069293711392768", want: 0x70B3D57ED0000000},
+		"max":        {value: "18446744073709551615", want: 0xFFFFFFFFFFFFFFFF},
+		"overflow":   {value: "18446744073709551616", wantErr: "must be between 0 and 18446744073709551615"},
+		"negative":   {value: "-1", wantErr: "must be between 0 and 18446744073709551615"},
+		"fractional": {value: "1.5", wantErr: "must be a whole number"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value, _, err := big.ParseFloat(testCase.value, 10, 512, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := decimalToUint64(value)
+
+			if testCase.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+					t.Errorf("got error %v, want %q", err, testCase.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("got %d, want %d", got, testCase.want)
+			}
+		})
+	}
+}
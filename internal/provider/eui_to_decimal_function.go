@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = EUIToDecimalFunction{}
+)
+
+func NewEUIToDecimalFunction() function.Function {
+	return EUIToDecimalFunction{}
+}
+
+type EUIToDecimalFunction struct{}
+
+func (r EUIToDecimalFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "eui_to_decimal"
+}
+
+func (r EUIToDecimalFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an EUI to a decimal number",
+		MarkdownDescription: "Converts an EUI in hexadecimal format to its value as a decimal number. The EUI is accepted in any of the forms of `eui_normalize`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "eui",
+				MarkdownDescription: "EUI in hexadecimal format",
+			},
+		},
+		Return: function.NumberReturn{},
+	}
+}
+
+func (r EUIToDecimalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var eui string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &eui))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := parseHexID(eui, 16)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid EUI: "+err.Error())
+		return
+	}
+
+	// EUIs use all 64 bits, which a float64 cannot represent exactly
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, new(big.Float).SetUint64(value)))
+}
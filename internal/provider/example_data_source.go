@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,7 +22,7 @@ func NewExampleDataSource() datasource.DataSource {
 
 // ExampleDataSource defines the data source implementation.
 type ExampleDataSource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // ExampleDataSourceModel describes the data source data model.
@@ -60,7 +59,7 @@ func (d *ExampleDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
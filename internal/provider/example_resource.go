@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,7 +27,7 @@ func NewExampleResource() resource.Resource {
 
 // ExampleResource defines the resource implementation.
 type ExampleResource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // ExampleResourceModel describes the resource data model.
@@ -75,7 +74,7 @@ func (r *ExampleResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &GatewayDataSource{}
+	_ datasource.DataSourceWithConfigure = &GatewayDataSource{}
+)
+
+func NewGatewayDataSource() datasource.DataSource {
+	return &GatewayDataSource{}
+}
+
+// GatewayDataSource defines the data source implementation.
+type GatewayDataSource struct {
+	client *loriotClient
+}
+
+// GatewayDataSourceModel describes the data source data model.
+type GatewayDataSourceModel struct {
+	ID               types.String          `tfsdk:"id"`
+	EUI              types.String          `tfsdk:"eui"`
+	MAC              types.String          `tfsdk:"mac"`
+	Title            types.String          `tfsdk:"title"`
+	NetworkId        types.String          `tfsdk:"network_id"`
+	Base             types.String          `tfsdk:"base"`
+	BaseName         types.String          `tfsdk:"base_name"`
+	Model            types.String          `tfsdk:"model"`
+	ModelName        types.String          `tfsdk:"model_name"`
+	Bus              types.String          `tfsdk:"bus"`
+	Concentrator     types.String          `tfsdk:"concentrator"`
+	ConcentratorName types.String          `tfsdk:"concentrator_name"`
+	Card             types.Int64           `tfsdk:"card"`
+	Region           types.String          `tfsdk:"region"`
+	Version          types.String          `tfsdk:"version"`
+	Connected        types.Bool            `tfsdk:"connected"`
+	IPAddress        types.String          `tfsdk:"ip_address"`
+	Location         *GatewayLocationModel `tfsdk:"location"`
+}
+
+func (d *GatewayDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway"
+}
+
+func (d *GatewayDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for a registered gateway",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Synonym for eui",
+				Computed:            true,
+			},
+			"eui": schema.StringAttribute{
+				MarkdownDescription: "Gateway EUI in hexadecimal format",
+				Required:            true,
+			},
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "Gateway MAC address",
+				Computed:            true,
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Gateway title",
+				Computed:            true,
+			},
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Roaming ID of the network the gateway is assigned to, in hexadecimal format",
+				Computed:            true,
+			},
+			"base": schema.StringAttribute{
+				MarkdownDescription: "Base station (manufacturer) of the gateway",
+				Computed:            true,
+			},
+			"base_name": schema.StringAttribute{
+				MarkdownDescription: "User friendly name of the base station",
+				Computed:            true,
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Model of the gateway",
+				Computed:            true,
+			},
+			"model_name": schema.StringAttribute{
+				MarkdownDescription: "User friendly name of the gateway model",
+				Computed:            true,
+			},
+			"bus": schema.StringAttribute{
+				MarkdownDescription: "Bus used to connect the concentrator",
+				Computed:            true,
+			},
+			"concentrator": schema.StringAttribute{
+				MarkdownDescription: "Model of the concentrator",
+				Computed:            true,
+			},
+			"concentrator_name": schema.StringAttribute{
+				MarkdownDescription: "User friendly name of the concentrator",
+				Computed:            true,
+			},
+			"card": schema.Int64Attribute{
+				MarkdownDescription: "ID of the concentrator card",
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "LoRaWAN region",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Software version reported by the gateway",
+				Computed:            true,
+			},
+			"connected": schema.BoolAttribute{
+				MarkdownDescription: "Gateway connection status",
+				Computed:            true,
+			},
+			"ip_address": schema.StringAttribute{
+				MarkdownDescription: "Public IP address of the gateway",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"location": schema.SingleNestedBlock{
+				MarkdownDescription: "Location of the gateway",
+				Attributes: map[string]schema.Attribute{
+					"latitude": schema.Float64Attribute{
+						MarkdownDescription: "GPS latitude",
+						Computed:            true,
+					},
+					"longitude": schema.Float64Attribute{
+						MarkdownDescription: "GPS longitude",
+						Computed:            true,
+					},
+					"address": schema.StringAttribute{
+						MarkdownDescription: "Postal address",
+						Computed:            true,
+					},
+					"city": schema.StringAttribute{
+						MarkdownDescription: "City",
+						Computed:            true,
+					},
+					"zip": schema.StringAttribute{
+						MarkdownDescription: "ZIP or postal code",
+						Computed:            true,
+					},
+					"country": schema.StringAttribute{
+						MarkdownDescription: "ISO country code",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *GatewayDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *GatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GatewayDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Gateway with EUI: %s", data.EUI.ValueString()))
+
+	gateway, httpResp, err := d.client.LoRaGatewayApi.V1NwkGatewayGWEUIGet(ctx, data.EUI.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read Gateway", httpResp, err))
+		return
+	}
+
+	data.ID = types.StringValue(gateway.Id)
+	data.EUI = types.StringValue(gateway.Id)
+	data.MAC = types.StringValue(gateway.MAC)
+	data.Title = types.StringValue(gateway.Title)
+	data.NetworkId = types.StringValue(gateway.RoamingId)
+	data.Base = types.StringValue(gateway.Base)
+	data.BaseName = types.StringValue(gateway.Basename)
+	data.Model = types.StringValue(gateway.Model)
+	data.ModelName = types.StringValue(gateway.Modelname)
+	data.Bus = types.StringValue(gateway.Bus)
+	data.Concentrator = types.StringValue(gateway.Concentrator)
+	data.ConcentratorName = types.StringValue(gateway.Concentratorname)
+	data.Card = types.Int64Value(int64(gateway.Card))
+	data.Region = types.StringValue(gateway.Region)
+	data.Version = types.StringValue(gateway.Version)
+	data.Connected = types.BoolValue(gateway.Connected)
+	data.IPAddress = types.StringValue(gateway.Ip)
+
+	if gateway.Location != nil {
+		data.Location = &GatewayLocationModel{
+			Latitude:  types.Float64Value(gateway.Location.Lat),
+			Longitude: types.Float64Value(gateway.Location.Lon),
+			Address:   types.StringValue(gateway.Location.Address),
+			City:      types.StringValue(gateway.Location.City),
+			Zip:       types.StringValue(gateway.Location.Zip),
+			Country:   types.StringValue(gateway.Location.Country),
+		}
+	} else {
+		data.Location = nil
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGatewayDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccGatewayDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_gateway.test", "id", "001122FFFE334455"),
+					resource.TestCheckResourceAttr("data.loriot_gateway.test", "title", "test"),
+					resource.TestCheckResourceAttr("data.loriot_gateway.test", "network_id", "0A0B0C0D"),
+					resource.TestCheckResourceAttr("data.loriot_gateway.test", "region", "EU868"),
+				),
+			},
+		},
+	})
+}
+
+const testAccGatewayDataSourceConfig = `
+resource "loriot_gateway" "test" {
+  mac          = "00:11:22:33:44:55"
+  title        = "test"
+  network_id   = "0A0B0C0D"
+  base         = "kerlink"
+  model        = "ifemtocell"
+  bus          = "SPI"
+  concentrator = "SX1301"
+}
+
+data "loriot_gateway" "test" {
+  eui = loriot_gateway.test.eui
+}
+`
@@ -0,0 +1,489 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GatewayResource{}
+var _ resource.ResourceWithImportState = &GatewayResource{}
+
+func NewGatewayResource() resource.Resource {
+	return &GatewayResource{}
+}
+
+// GatewayResource defines the resource implementation.
+type GatewayResource struct {
+	client *loriotClient
+}
+
+// GatewayResourceModel describes the resource data model.
+type GatewayResourceModel struct {
+	EUI          types.String          `tfsdk:"eui"`
+	MAC          types.String          `tfsdk:"mac"`
+	Title        types.String          `tfsdk:"title"`
+	NetworkId    types.String          `tfsdk:"network_id"`
+	Base         types.String          `tfsdk:"base"`
+	Model        types.String          `tfsdk:"model"`
+	Bus          types.String          `tfsdk:"bus"`
+	Concentrator types.String          `tfsdk:"concentrator"`
+	Card         types.Int64           `tfsdk:"card"`
+	Location     *GatewayLocationModel `tfsdk:"location"`
+}
+
+type GatewayLocationModel struct {
+	Latitude  types.Float64 `tfsdk:"latitude"`
+	Longitude types.Float64 `tfsdk:"longitude"`
+	Address   types.String  `tfsdk:"address"`
+	City      types.String  `tfsdk:"city"`
+	Zip       types.String  `tfsdk:"zip"`
+	Country   types.String  `tfsdk:"country"`
+}
+
+func (r *GatewayResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway"
+}
+
+func (r *GatewayResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Gateway resource",
+
+		Attributes: map[string]schema.Attribute{
+			"eui": schema.StringAttribute{
+				MarkdownDescription: "Gateway EUI in hexadecimal format. Derived from the MAC address unless set, in which case it is registered as a custom EUI",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					hexStringValidator(16),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "Gateway MAC address",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Gateway title",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Roaming ID of the network the gateway is assigned to, in hexadecimal format",
+				Required:            true,
+			},
+			"base": schema.StringAttribute{
+				MarkdownDescription: "Base station (manufacturer) of the gateway",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Model of the gateway",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bus": schema.StringAttribute{
+				MarkdownDescription: "Bus used to connect the concentrator",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"concentrator": schema.StringAttribute{
+				MarkdownDescription: "Model of the concentrator",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"card": schema.Int64Attribute{
+				MarkdownDescription: "ID of the concentrator card",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"location": schema.SingleNestedBlock{
+				MarkdownDescription: "Location of the gateway. Removing the block clears the location of the gateway",
+				Attributes: map[string]schema.Attribute{
+					"latitude": schema.Float64Attribute{
+						MarkdownDescription: "GPS latitude",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(-90, 90),
+						},
+					},
+					"longitude": schema.Float64Attribute{
+						MarkdownDescription: "GPS longitude",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(-180, 180),
+						},
+					},
+					"address": schema.StringAttribute{
+						MarkdownDescription: "Postal address",
+						Optional:            true,
+					},
+					"city": schema.StringAttribute{
+						MarkdownDescription: "City",
+						Optional:            true,
+					},
+					"zip": schema.StringAttribute{
+						MarkdownDescription: "ZIP or postal code",
+						Optional:            true,
+					},
+					"country": schema.StringAttribute{
+						MarkdownDescription: "ISO country code",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *GatewayResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GatewayResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := loriot.HexIdGatewaysBody{
+		Base:         data.Base.ValueString(),
+		Model:        data.Model.ValueString(),
+		MAC:          data.MAC.ValueString(),
+		Bus:          data.Bus.ValueString(),
+		Concentrator: data.Concentrator.ValueString(),
+		Card:         float64(data.Card.ValueInt64()),
+		Location:     data.Location.locationBody(),
+	}
+
+	if !data.EUI.IsUnknown() && !data.EUI.IsNull() {
+		body.CustomEUI = data.EUI.ValueString()
+	}
+
+	opts := loriot.LoRaNetworkApi1NwkNetworkHexIdGatewaysPostOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	gateway, httpResp, err := r.client.LoRaNetworkApi.V1NwkNetworkHexIdGatewaysPost(ctx, data.NetworkId.ValueString(), &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create Gateway", httpResp, err))
+		return
+	}
+
+	if data.EUI.IsUnknown() {
+		data.EUI = types.StringValue(gateway.Id)
+	}
+
+	// The registration API does not accept a title, so set it afterwards
+	if !data.Title.IsUnknown() {
+		updateOpts := loriot.LoRaGatewayApi1NwkGatewayGWEUIPostOpts{
+			Body: optional.NewInterface(loriot.GatewayGweuiBody{
+				Title: data.Title.ValueString(),
+			}),
+		}
+
+		httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIPost(ctx, data.EUI.ValueString(), &updateOpts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update title of Gateway", httpResp, err))
+			return
+		}
+	}
+
+	// Re-read the gateway to fill in the values set by the network server
+	details, httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIGet(ctx, data.EUI.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read Gateway", httpResp, err))
+		return
+	}
+
+	data.readGateway(details)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GatewayResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Gateway with EUI %s", data.EUI.ValueString()))
+
+	gateway, httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIGet(ctx, data.EUI.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("Gateway %s no longer exists, removing from state", data.EUI.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read Gateway", httpResp, err))
+		return
+	}
+
+	data.readGateway(gateway)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, data GatewayResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eui := state.EUI.ValueString()
+
+	// Changes to the network are made by moving the gateway to the target network
+	if !data.NetworkId.Equal(state.NetworkId) {
+		httpResp, err := r.client.LoRaNetworkApi.V1NwkNetworkHexIdGatewayGWEUIMoveTargetroamingidPut(ctx, state.NetworkId.ValueString(), eui, data.NetworkId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(clientError(fmt.Sprintf("Unable to move Gateway to network %s", data.NetworkId.ValueString()), httpResp, err))
+			return
+		}
+	}
+
+	if !data.Title.Equal(state.Title) || !data.Location.equal(state.Location) {
+		body := gatewayUpdateBody{
+			Title:    data.Title.ValueString(),
+			Location: data.Location.updateLocationBody(),
+		}
+
+		// A removed location is cleared, rather than left on the gateway
+		if body.Location == nil && state.Location != nil {
+			body.Location = &gatewayLocationBody{}
+		}
+
+		opts := loriot.LoRaGatewayApi1NwkGatewayGWEUIPostOpts{
+			Body: optional.NewInterface(body),
+		}
+
+		httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIPost(ctx, eui, &opts)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError("Unable to update Gateway", httpResp, err))
+			return
+		}
+	}
+
+	// Re-read the gateway to ensure the most up-to-date version is returned
+	gateway, httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIGet(ctx, eui)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read Gateway", httpResp, err))
+		return
+	}
+
+	data.readGateway(gateway)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GatewayResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Gateway may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaGatewayApi.V1NwkGatewayGWEUIDelete(ctx, data.EUI.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete Gateway", httpResp, err))
+		return
+	}
+}
+
+func (r *GatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("eui"), req, resp)
+}
+
+// gatewayUpdateBody is the body of the gateway update API. The client's
+// location model omits empty fields, which the API leaves unchanged, so the
+// location is sent with every field to clear those which were removed.
+type gatewayUpdateBody struct {
+	Title    string               `json:"title,omitempty"`
+	Location *gatewayLocationBody `json:"location,omitempty"`
+}
+
+type gatewayLocationBody struct {
+	Address string  `json:"address"`
+	City    string  `json:"city"`
+	Zip     string  `json:"zip"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+// readGateway copies the values returned by the API into the model. The
+// location is only refreshed when it is managed, as the network server
+// otherwise fills it in from the network's location.
+func (m *GatewayResourceModel) readGateway(gateway loriot.InlineResponse20035) {
+	if !strings.EqualFold(m.EUI.ValueString(), gateway.Id) {
+		m.EUI = types.StringValue(gateway.Id)
+	}
+
+	if !strings.EqualFold(m.MAC.ValueString(), gateway.MAC) {
+		m.MAC = types.StringValue(gateway.MAC)
+	}
+
+	m.Title = types.StringValue(gateway.Title)
+	m.NetworkId = types.StringValue(gateway.RoamingId)
+	m.Base = types.StringValue(gateway.Base)
+	m.Model = types.StringValue(gateway.Model)
+	m.Bus = types.StringValue(gateway.Bus)
+	m.Concentrator = types.StringValue(gateway.Concentrator)
+	m.Card = types.Int64Value(int64(gateway.Card))
+
+	if m.Location != nil && gateway.Location != nil {
+		m.Location.readLocation(*gateway.Location)
+	}
+}
+
+func (m *GatewayLocationModel) locationBody() *loriot.Location {
+	if m == nil {
+		return nil
+	}
+
+	return &loriot.Location{
+		Lat:     m.Latitude.ValueFloat64(),
+		Lon:     m.Longitude.ValueFloat64(),
+		Address: m.Address.ValueString(),
+		City:    m.City.ValueString(),
+		Zip:     m.Zip.ValueString(),
+		Country: m.Country.ValueString(),
+	}
+}
+
+func (m *GatewayLocationModel) updateLocationBody() *gatewayLocationBody {
+	if m == nil {
+		return nil
+	}
+
+	return &gatewayLocationBody{
+		Address: m.Address.ValueString(),
+		City:    m.City.ValueString(),
+		Zip:     m.Zip.ValueString(),
+		Country: m.Country.ValueString(),
+		Lat:     m.Latitude.ValueFloat64(),
+		Lon:     m.Longitude.ValueFloat64(),
+	}
+}
+
+// readLocation refreshes the configured location attributes, leaving those
+// which are not configured as null.
+func (m *GatewayLocationModel) readLocation(location loriot.Location) {
+	if !m.Latitude.IsNull() {
+		m.Latitude = types.Float64Value(location.Lat)
+	}
+
+	if !m.Longitude.IsNull() {
+		m.Longitude = types.Float64Value(location.Lon)
+	}
+
+	if !m.Address.IsNull() {
+		m.Address = types.StringValue(location.Address)
+	}
+
+	if !m.City.IsNull() {
+		m.City = types.StringValue(location.City)
+	}
+
+	if !m.Zip.IsNull() {
+		m.Zip = types.StringValue(location.Zip)
+	}
+
+	if !m.Country.IsNull() {
+		m.Country = types.StringValue(location.Country)
+	}
+}
+
+func (m *GatewayLocationModel) equal(other *GatewayLocationModel) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+
+	return m.Latitude.Equal(other.Latitude) && m.Longitude.Equal(other.Longitude) &&
+		m.Address.Equal(other.Address) && m.City.Equal(other.City) &&
+		m.Zip.Equal(other.Zip) && m.Country.Equal(other.Country)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccGatewayResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccGatewayResourceConfig("one", "0A0B0C0D", "Zurich"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_gateway.test", "eui", "001122FFFE334455"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "title", "one"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "network_id", "0A0B0C0D"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "card", "0"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "location.city", "Zurich"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_gateway.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_gateway.test", "eui"),
+				ImportStateVerifyIdentifierAttribute: "eui",
+				// The location is only refreshed when it is managed
+				ImportStateVerifyIgnore: []string{"location"},
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccGatewayResourceConfig("two", "0A0B0C0E", "Geneva"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_gateway.test", "eui", "001122FFFE334455"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "title", "two"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "network_id", "0A0B0C0E"),
+					resource.TestCheckResourceAttr("loriot_gateway.test", "location.city", "Geneva"),
+				),
+			},
+			// Location removal testing
+			{
+				Config: server.providerConfig() + testAccGatewayResourceNoLocationConfig("two", "0A0B0C0E"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_gateway.test", "location.city"),
+					func(s *terraform.State) error {
+						gateway, _, err := server.client().LoRaGatewayApi.V1NwkGatewayGWEUIGet(context.Background(), "001122FFFE334455")
+						if err != nil {
+							return err
+						}
+
+						if gateway.Location != nil && gateway.Location.City != "" {
+							return fmt.Errorf("expected location to be cleared, got city %q", gateway.Location.City)
+						}
+
+						return nil
+					},
+				),
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					if _, err := server.client().LoRaGatewayApi.V1NwkGatewayGWEUIDelete(context.Background(), "001122FFFE334455"); err != nil {
+						t.Fatalf("unable to delete Gateway: %s", err)
+					}
+				},
+				Config:             server.providerConfig() + testAccGatewayResourceConfig("two", "0A0B0C0E", "Geneva"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccGatewayResourceConfig(title string, networkId string, city string) string {
+	return fmt.Sprintf(`
+resource "loriot_gateway" "test" {
+  mac          = "00:11:22:33:44:55"
+  title        = %[1]q
+  network_id   = %[2]q
+  base         = "kerlink"
+  model        = "ifemtocell"
+  bus          = "SPI"
+  concentrator = "SX1301"
+
+  location {
+    latitude  = 47.3769
+    longitude = 8.5417
+    city      = %[3]q
+    country   = "CH"
+  }
+}
+`, title, networkId, city)
+}
+
+func testAccGatewayResourceNoLocationConfig(title string, networkId string) string {
+	return fmt.Sprintf(`
+resource "loriot_gateway" "test" {
+  mac          = "00:11:22:33:44:55"
+  title        = %[1]q
+  network_id   = %[2]q
+  base         = "kerlink"
+  model        = "ifemtocell"
+  bus          = "SPI"
+  concentrator = "SX1301"
+}
+`, title, networkId)
+}
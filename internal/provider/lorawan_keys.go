@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// aesKeyLength is the length in bytes of the AES-128 keys used by LoRaWAN.
+const aesKeyLength = 16
+
+// Key derivation prefixes defined by the LoRaWAN specifications, which are
+// the first byte of the block encrypted to derive each key.
+const (
+	nwkSKeyPrefix  = 0x01 // LoRaWAN 1.0.x NwkSKey
+	appSKeyPrefix  = 0x02 // LoRaWAN 1.0.x AppSKey
+	jsEncKeyPrefix = 0x05 // LoRaWAN 1.1 JSEncKey
+	jsIntKeyPrefix = 0x06 // LoRaWAN 1.1 JSIntKey
+)
+
+// parseAESKey parses an AES-128 key of 32 hexadecimal characters, ignoring
+// case and any separators.
+func parseAESKey(value string) ([]byte, error) {
+	digits := stripSeparators(value)
+
+	if len(digits) != 2*aesKeyLength {
+		return nil, fmt.Errorf("key must be %d hexadecimal characters, excluding separators", 2*aesKeyLength)
+	}
+
+	key, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, errors.New("key must only contain hexadecimal characters and separators")
+	}
+
+	return key, nil
+}
+
+// formatAESKey returns a key as 32 uppercase hexadecimal characters, the form
+// used by Loriot.
+func formatAESKey(key []byte) string {
+	return hexValue(key)
+}
+
+// deriveKey encrypts the block made up of prefix followed by fields with key,
+// padded with zeros to the AES block size. The fields are written least
+// significant byte first, as they are sent over the air.
+func deriveKey(key []byte, prefix byte, fields ...[]byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	input := make([]byte, 1, aes.BlockSize)
+	input[0] = prefix
+
+	for _, field := range fields {
+		input = append(input, field...)
+	}
+
+	if len(input) > aes.BlockSize {
+		return nil, fmt.Errorf("key derivation input of %d bytes exceeds the block size", len(input))
+	}
+
+	input = input[:aes.BlockSize]
+
+	derived := make([]byte, aes.BlockSize)
+	block.Encrypt(derived, input)
+
+	return derived, nil
+}
+
+// littleEndian returns the size least significant bytes of value, least
+// significant first.
+func littleEndian(value uint64, size int) []byte {
+	buf := binary.LittleEndian.AppendUint64(nil, value)
+
+	return buf[:size]
+}
+
+// deriveSessionKeys derives the LoRaWAN 1.0.x network and application session
+// keys of a device from its AppKey and the JoinNonce (AppNonce), NetID and
+// DevNonce exchanged when it joined:
+//
+//	NwkSKey = aes128_encrypt(AppKey, 0x01 | JoinNonce | NetID | DevNonce | pad16)
+//	AppSKey = aes128_encrypt(AppKey, 0x02 | JoinNonce | NetID | DevNonce | pad16)
+func deriveSessionKeys(appKey []byte, joinNonce uint64, netId uint64, devNonce uint64) ([]byte, []byte, error) {
+	fields := [][]byte{
+		littleEndian(joinNonce, 3),
+		littleEndian(netId, 3),
+		littleEndian(devNonce, 2),
+	}
+
+	nwkSKey, err := deriveKey(appKey, nwkSKeyPrefix, fields...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appSKey, err := deriveKey(appKey, appSKeyPrefix, fields...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nwkSKey, appSKey, nil
+}
+
+// deriveJoinServerKeys derives the LoRaWAN 1.1 join server integrity and
+// encryption keys of a device from its NwkKey and DevEUI:
+//
+//	JSIntKey = aes128_encrypt(NwkKey, 0x06 | DevEUI | pad16)
+//	JSEncKey = aes128_encrypt(NwkKey, 0x05 | DevEUI | pad16)
+func deriveJoinServerKeys(nwkKey []byte, devEUI uint64) ([]byte, []byte, error) {
+	jsIntKey, err := deriveKey(nwkKey, jsIntKeyPrefix, littleEndian(devEUI, 8))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jsEncKey, err := deriveKey(nwkKey, jsEncKeyPrefix, littleEndian(devEUI, 8))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return jsIntKey, jsEncKey, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// The derivation test vectors use the AES-128 key of FIPS-197 appendix A.1,
+// and were computed independently by encrypting the derivation blocks with
+// openssl enc -aes-128-ecb -nopad.
+const testAppKey = "2B7E151628AED2A6ABF7158809CF4F3C"
+
+func TestDeriveKey(t *testing.T) {
+	// FIPS-197 appendix C.1, with the plaintext as prefix and fields
+	key, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F")
+	plaintext, _ := hex.DecodeString("00112233445566778899AABBCCDDEEFF")
+
+	got, err := deriveKey(key, plaintext[0], plaintext[1:])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "69C4E0D86A7B0430D8CDB78070B4C55A"; formatAESKey(got) != want {
+		t.Errorf("got %s, want %s", formatAESKey(got), want)
+	}
+
+	if _, err := deriveKey(key, 0x01, plaintext); err == nil {
+		t.Error("expected error for input exceeding the block size")
+	}
+}
+
+func TestDeriveSessionKeys(t *testing.T) {
+	appKey, err := parseAESKey(testAppKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	nwkSKey, appSKey, err := deriveSessionKeys(appKey, 0x123456, 0x000013, 0xABCD)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "C670E85756697041D692598007458424"; formatAESKey(nwkSKey) != want {
+		t.Errorf("got NwkSKey %s, want %s", formatAESKey(nwkSKey), want)
+	}
+
+	if want := "0FF0A4657A03CA86A5740FF1DA314E3D"; formatAESKey(appSKey) != want {
+		t.Errorf("got AppSKey %s, want %s", formatAESKey(appSKey), want)
+	}
+}
+
+func TestDeriveJoinServerKeys(t *testing.T) {
+	nwkKey, err := parseAESKey(testAppKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	jsIntKey, jsEncKey, err := deriveJoinServerKeys(nwkKey, 0x70B3D57ED0000001)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "CF93C3690FD96A769FAA548EB3CDE704"; formatAESKey(jsIntKey) != want {
+		t.Errorf("got JSIntKey %s, want %s", formatAESKey(jsIntKey), want)
+	}
+
+	if want := "DCEEF1D17EB97662F884D07BC0C28A8E"; formatAESKey(jsEncKey) != want {
+		t.Errorf("got JSEncKey %s, want %s", formatAESKey(jsEncKey), want)
+	}
+}
+
+func TestParseAESKey(t *testing.T) {
+	want, _ := hex.DecodeString(testAppKey)
+
+	for _, value := range []string{testAppKey, strings.ToLower(testAppKey), "2B:7E:15:16:28:AE:D2:A6:AB:F7:15:88:09:CF:4F:3C"} {
+		got, err := parseAESKey(value)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", value, err)
+			continue
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("got %X for %q, want %X", got, value, want)
+		}
+	}
+
+	testCases := map[string]string{
+		"short":   "2B7E151628AED2A6ABF7158809CF4F",
+		"not-hex": "2B7E151628AED2A6ABF7158809CF4FXY",
+	}
+
+	for name, value := range testCases {
+		if _, err := parseAESKey(value); err == nil {
+			t.Errorf("%s: expected error for %q", name, value)
+		} else if strings.Contains(err.Error(), value) {
+			t.Errorf("%s: error %q includes the key", name, err)
+		}
+	}
+}
@@ -0,0 +1,1006 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// mockAPIKey is the API key accepted by the mock Loriot server.
+const mockAPIKey = "mock-api-key"
+
+// mockLoriotServer is an in-process fake of the Loriot network server API. It
+// holds enough state for the acceptance tests to create, read, update, import
+// and destroy every resource without access to a real network server.
+type mockLoriotServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	nextAppId float64
+	nextToken int
+	user      loriot.User
+	apps      map[string]*mockApp
+	gateways  map[string]*loriot.InlineResponse20035
+}
+
+type mockApp struct {
+	loriot.Application
+	outputs      []loriot.OutputInfo
+	nextOutputId float64
+	tokens       []string
+	devices      map[string]*mockDevice
+	mcastDevices map[string]*loriot.Mcastdev
+}
+
+// mockDevice extends the client's device model with the attributes returned by
+// the network server which the generated client does not model.
+type mockDevice struct {
+	loriot.Device
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Appkey      string `json:"-"`
+	Nwkkey      string `json:"-"`
+	Nwkskey     string `json:"-"`
+	Appskey     string `json:"-"`
+}
+
+// newMockLoriotServer starts a mock Loriot server which is closed when the
+// test completes.
+func newMockLoriotServer(t *testing.T) *mockLoriotServer {
+	t.Helper()
+
+	m := &mockLoriotServer{
+		nextAppId: 0xBE010000,
+		user: loriot.User{
+			Userid:           1,
+			Email:            "user@example.com",
+			FirstName:        "Test",
+			LastName:         "User",
+			Level:            1,
+			Tier:             1,
+			Devlimit:         1000,
+			Gwlimit:          10,
+			Mcastdevlimit:    10,
+			OutputLimit:      10,
+			OrganizationRole: "owner",
+			OrganizationUuid: "00000000-0000-0000-0000-000000000001",
+		},
+		apps:     map[string]*mockApp{},
+		gateways: map[string]*loriot.InlineResponse20035{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /1/nwk/user", m.getUser)
+	mux.HandleFunc("GET /1/nwk/user/usage", m.getUserUsage)
+
+	mux.HandleFunc("GET /1/nwk/apps", m.listApps)
+	mux.HandleFunc("POST /1/nwk/apps", m.createApp)
+	mux.HandleFunc("GET /1/nwk/app/{APPID}", m.withApp(m.getApp))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}", m.withApp(m.updateApp))
+	mux.HandleFunc("DELETE /1/nwk/app/{APPID}", m.withApp(m.deleteApp))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/title", m.withApp(m.updateAppTitle))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/capacity", m.withApp(m.updateAppCapacity))
+	mux.HandleFunc("PUT /1/nwk/app/{APPID}/cfg_dev_base", m.withApp(m.updateAppCfgDevBase))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/mcastdevlimit", m.withApp(m.updateAppMcastDevLimit))
+
+	mux.HandleFunc("GET /1/nwk/app/{APPID}/mcast-device", m.withApp(m.listMcastDevices))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/mcast-device", m.withApp(m.createMcastDevice))
+	mux.HandleFunc("PUT /1/nwk/app/{APPID}/mcast-device/{MCASTEUI}", m.withApp(m.updateMcastDevice))
+	mux.HandleFunc("DELETE /1/nwk/app/{APPID}/mcast-device/{MCASTEUI}", m.withApp(m.deleteMcastDevice))
+
+	mux.HandleFunc("GET /1/nwk/app/{APPID}/token", m.withApp(m.listTokens))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/token", m.withApp(m.createToken))
+	mux.HandleFunc("DELETE /1/nwk/app/{APPID}/token/{TOKEN}", m.withApp(m.deleteToken))
+
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/outputs", m.withApp(m.createOutput))
+	mux.HandleFunc("PUT /1/nwk/app/{APPID}/outputs/{OUTPUTID}", m.withApp(m.updateOutput))
+	mux.HandleFunc("DELETE /1/nwk/app/{APPID}/outputs/{OUTPUTID}", m.withApp(m.deleteOutput))
+
+	mux.HandleFunc("GET /1/nwk/app/{APPID}/devices", m.withApp(m.listDevices))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/devices/otaa", m.withApp(m.createOTAADevice))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/devices/abp", m.withApp(m.createABPDevice))
+	mux.HandleFunc("GET /1/nwk/app/{APPID}/device/{DEVEUI}", m.withDevice(m.getDevice))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/device/{DEVEUI}", m.withDevice(m.updateDevice))
+	mux.HandleFunc("DELETE /1/nwk/app/{APPID}/device/{DEVEUI}", m.withDevice(m.deleteDevice))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/device/{DEVEUI}/appkey", m.withDevice(m.updateDeviceAppKey))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/device/{DEVEUI}/appskey", m.withDevice(m.updateDeviceAppSKey))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/device/{DEVEUI}/seqno", m.withDevice(m.resetDeviceSeqno))
+	mux.HandleFunc("POST /1/nwk/app/{APPID}/device/{DEVEUI}/seqdn", m.withDevice(m.resetDeviceSeqdn))
+
+	mux.HandleFunc("POST /1/nwk/network/{hexId}/gateways", m.createGateway)
+	mux.HandleFunc("PUT /1/nwk/network/{hexId}/gateway/{GWEUI}/move/{targetroamingid}", m.moveGateway)
+	mux.HandleFunc("GET /1/nwk/gateway/{GWEUI}", m.getGateway)
+	mux.HandleFunc("POST /1/nwk/gateway/{GWEUI}", m.updateGateway)
+	mux.HandleFunc("DELETE /1/nwk/gateway/{GWEUI}", m.deleteGateway)
+
+	m.Server = httptest.NewServer(m.authenticate(mux))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// providerConfig returns a provider block pointing at the mock server.
+func (m *mockLoriotServer) providerConfig() string {
+	return fmt.Sprintf(`
+provider "loriot" {
+  host = %[1]q
+  key  = %[2]q
+}
+`, m.URL, mockAPIKey)
+}
+
+// client returns an API client for the mock server, which tests use to make
+// changes outside of Terraform.
+func (m *mockLoriotServer) client() *loriot.APIClient {
+	cfg := loriot.NewConfiguration()
+	cfg.BasePath = m.URL
+	cfg.AddDefaultHeader("Authorization", "Bearer "+mockAPIKey)
+
+	return loriot.NewAPIClient(cfg)
+}
+
+// testCheckDeviceKeys checks the keys a device was registered or updated with,
+// which the API never returns, so write-only keys can be verified.
+func (m *mockLoriotServer) testCheckDeviceKeys(appId string, devEUI string, want mockDevice) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		app, ok := m.apps[appId]
+		if !ok {
+			return fmt.Errorf("app %s not found", appId)
+		}
+
+		device, ok := app.devices[devEUI]
+		if !ok {
+			return fmt.Errorf("device %s not found in app %s", devEUI, appId)
+		}
+
+		got := [...]string{device.Appkey, device.Nwkkey, device.Nwkskey, device.Appskey}
+		if expected := [...]string{want.Appkey, want.Nwkkey, want.Nwkskey, want.Appskey}; got != expected {
+			return fmt.Errorf("expected device %s to have keys %q, got %q", devEUI, expected, got)
+		}
+
+		return nil
+	}
+}
+
+// changeDevice returns a PreConfig function which changes a device as traffic
+// would, such as advancing its frame counters.
+func (m *mockLoriotServer) changeDevice(t *testing.T, appId string, devEUI string, change func(device *mockDevice)) func() {
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		app, ok := m.apps[appId]
+		if !ok {
+			t.Fatalf("app %s not found", appId)
+		}
+
+		device, ok := app.devices[devEUI]
+		if !ok {
+			t.Fatalf("device %s not found in app %s", devEUI, appId)
+		}
+
+		change(device)
+	}
+}
+
+// authenticate rejects requests without the mock API key or an application
+// token, and serialises access to the server state. Application
+// tokens only allow requests to their application.
+func (m *mockLoriotServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		switch authorization := r.Header.Get("Authorization"); {
+		case authorization == "Bearer "+mockAPIKey:
+		case strings.HasPrefix(authorization, "Bearer ") && m.appTokenAllows(strings.TrimPrefix(authorization, "Bearer "), r.URL.Path):
+		default:
+			mockError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// appTokenAllows reports whether token is a token of the application path
+// refers to.
+func (m *mockLoriotServer) appTokenAllows(token string, path string) bool {
+	for appId, app := range m.apps {
+		if path != "/1/nwk/app/"+appId && !strings.HasPrefix(path, "/1/nwk/app/"+appId+"/") {
+			continue
+		}
+
+		for _, appToken := range app.tokens {
+			if appToken == token {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (m *mockLoriotServer) withApp(next func(http.ResponseWriter, *http.Request, *mockApp)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		app, ok := m.apps[strings.ToUpper(r.PathValue("APPID"))]
+		if !ok {
+			mockError(w, http.StatusNotFound, "application not found")
+			return
+		}
+
+		next(w, r, app)
+	}
+}
+
+func (m *mockLoriotServer) withDevice(next func(http.ResponseWriter, *http.Request, *mockApp, *mockDevice)) http.HandlerFunc {
+	return m.withApp(func(w http.ResponseWriter, r *http.Request, app *mockApp) {
+		device, ok := app.devices[strings.ToUpper(r.PathValue("DEVEUI"))]
+		if !ok {
+			mockError(w, http.StatusNotFound, "device not found")
+			return
+		}
+
+		next(w, r, app, device)
+	})
+}
+
+func (m *mockLoriotServer) getUser(w http.ResponseWriter, r *http.Request) {
+	mockJSON(w, m.user)
+}
+
+func (m *mockLoriotServer) getUserUsage(w http.ResponseWriter, r *http.Request) {
+	usage := loriot.InlineResponse20029{
+		Apps:         float64(len(m.apps)),
+		Devlimit:     m.user.Devlimit,
+		Gateways:     float64(len(m.gateways)),
+		Gwlimit:      m.user.Gwlimit,
+		Mcastdevices: m.user.Mcastdevlimit,
+	}
+
+	for _, app := range m.apps {
+		usage.Devices += float64(len(app.devices))
+		usage.Devuse += app.DeviceLimit
+		usage.Mcastdevuse += app.Mcastdevlimit
+	}
+
+	mockJSON(w, usage)
+}
+
+func (m *mockLoriotServer) listApps(w http.ResponseWriter, r *http.Request) {
+	ids := make([]string, 0, len(m.apps))
+	for id := range m.apps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	apps := make([]loriot.Application, 0, len(ids))
+	for _, id := range ids {
+		apps = append(apps, m.apps[id].application())
+	}
+
+	page, perPage := mockPage(r, len(apps))
+	start, end := mockPageBounds(page, perPage, len(apps))
+	pageApps := apps[start:end]
+
+	mockJSON(w, loriot.InlineResponse2008{
+		Page:    float64(page),
+		PerPage: float64(perPage),
+		Total:   float64(len(apps)),
+		Apps:    &pageApps,
+	})
+}
+
+func (m *mockLoriotServer) createApp(w http.ResponseWriter, r *http.Request) {
+	var body appCreateBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	if body.Title == "" || int64(body.Capacity)%10 != 0 || !mockValidVisibility(body.Visibility) {
+		mockError(w, http.StatusUnprocessableEntity, "invalid application")
+		return
+	}
+
+	organizationId := body.OrganizationId
+	if organizationId == 0 {
+		organizationId = 1
+	}
+
+	id := m.nextAppId
+	m.nextAppId++
+
+	app := &mockApp{
+		Application: loriot.Application{
+			Id:             id,
+			AppHexId:       fmt.Sprintf("%08X", int64(id)),
+			Name:           body.Title,
+			Ownerid:        m.user.Userid,
+			OrganizationId: organizationId,
+			Visibility:     body.Visibility,
+			Created:        time.Now().UTC().Format(time.RFC3339),
+			DeviceLimit:    body.Capacity,
+			Mcastdevlimit:  body.Mcastdevlimit,
+		},
+		devices:      map[string]*mockDevice{},
+		mcastDevices: map[string]*loriot.Mcastdev{},
+	}
+
+	m.apps[app.AppHexId] = app
+
+	mockJSON(w, loriot.ApplicationPostResponse{
+		Id:             app.Id,
+		AppHexId:       app.AppHexId,
+		Name:           app.Name,
+		Created:        app.Created,
+		DeviceLimit:    app.DeviceLimit,
+		Visibility:     app.Visibility,
+		Ownerid:        app.Ownerid,
+		OrganizationId: app.OrganizationId,
+		Mcastdevlimit:  app.Mcastdevlimit,
+	})
+}
+
+// mockValidVisibility reports whether visibility is empty or one of the
+// values the Loriot API accepts.
+func mockValidVisibility(visibility string) bool {
+	return visibility == "" || visibility == "private" || visibility == "public"
+}
+
+func (m *mockLoriotServer) getApp(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	mockJSON(w, app.application())
+}
+
+func (m *mockLoriotServer) updateApp(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.AppAppidBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	if !mockValidVisibility(body.Visibility) {
+		mockError(w, http.StatusUnprocessableEntity, "invalid visibility")
+		return
+	}
+
+	if body.Visibility != "" {
+		app.Visibility = body.Visibility
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) deleteApp(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	delete(m.apps, app.AppHexId)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) updateAppTitle(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.AppidTitleBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	app.Name = body.Title
+	mockJSON(w, loriot.InlineResponse20013{Title: app.Name})
+}
+
+func (m *mockLoriotServer) updateAppCapacity(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.AppidCapacityBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	limit := app.DeviceLimit + body.Inc - body.Dec
+	if limit < float64(len(app.devices)) || int64(limit)%10 != 0 {
+		mockError(w, http.StatusUnprocessableEntity, "invalid capacity")
+		return
+	}
+
+	app.DeviceLimit = limit
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) updateAppMcastDevLimit(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body appMcastDevLimitBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	if body.Mcastdevlimit < app.Mcastdevices {
+		mockError(w, http.StatusBadRequest, "invalid multicast device limit")
+		return
+	}
+
+	app.Mcastdevlimit = body.Mcastdevlimit
+	mockJSON(w, loriot.InlineResponse20011{Mcastdevlimit: app.Mcastdevlimit})
+}
+
+func (m *mockLoriotServer) updateAppCfgDevBase(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.ApplicationCfgDevBase
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	app.CfgDevBase = &body
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) listMcastDevices(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	euis := make([]string, 0, len(app.mcastDevices))
+	for eui := range app.mcastDevices {
+		euis = append(euis, eui)
+	}
+	sort.Strings(euis)
+
+	page, perPage := mockPage(r, len(euis))
+	start, end := mockPageBounds(page, perPage, len(euis))
+
+	devices := make([]loriot.Mcastdev, 0, end-start)
+	for _, eui := range euis[start:end] {
+		devices = append(devices, *app.mcastDevices[eui])
+	}
+
+	mockJSON(w, devices)
+}
+
+func (m *mockLoriotServer) createMcastDevice(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.Mcast
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	eui := strings.ToUpper(body.Mcasteui)
+	if _, ok := app.mcastDevices[eui]; ok {
+		mockError(w, http.StatusConflict, "multicast device already exists")
+		return
+	}
+
+	if float64(len(app.mcastDevices)) >= app.Mcastdevlimit {
+		mockError(w, http.StatusUnprocessableEntity, "application multicast device limit reached")
+		return
+	}
+
+	device := &loriot.Mcastdev{
+		Id:    eui,
+		Seqdn: body.Seqdn,
+		// The network server applies a default preload when none is given
+		Preload: 600000,
+	}
+	mockUpdateMcastDevice(device, body)
+
+	app.mcastDevices[eui] = device
+	app.Mcastdevices = float64(len(app.mcastDevices))
+	mockJSON(w, device)
+}
+
+func (m *mockLoriotServer) updateMcastDevice(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	device, ok := app.mcastDevices[strings.ToUpper(r.PathValue("MCASTEUI"))]
+	if !ok {
+		mockError(w, http.StatusNotFound, "multicast device not found")
+		return
+	}
+
+	var body loriot.Mcast
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	mockUpdateMcastDevice(device, body)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) deleteMcastDevice(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	eui := strings.ToUpper(r.PathValue("MCASTEUI"))
+	if _, ok := app.mcastDevices[eui]; !ok {
+		mockError(w, http.StatusNotFound, "multicast device not found")
+		return
+	}
+
+	delete(app.mcastDevices, eui)
+	app.Mcastdevices = float64(len(app.mcastDevices))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mockUpdateMcastDevice copies the settings in body to device, leaving those
+// which are not set unchanged.
+func mockUpdateMcastDevice(device *loriot.Mcastdev, body loriot.Mcast) {
+	if body.Devaddr != "" {
+		device.Devaddr = strings.ToUpper(body.Devaddr)
+	}
+
+	if body.Nwkskey != "" {
+		device.Nwkskey = strings.ToUpper(body.Nwkskey)
+	}
+
+	if body.Appskey != "" {
+		device.Appskey = strings.ToUpper(body.Appskey)
+	}
+
+	if body.Datr != "" {
+		device.Datr = body.Datr
+	}
+
+	if body.Freq != 0 {
+		device.Freq = body.Freq
+	}
+
+	if body.Preload != 0 {
+		device.Preload = body.Preload
+	}
+}
+
+func (m *mockLoriotServer) listTokens(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	mockJSON(w, append([]string{}, app.tokens...))
+}
+
+func (m *mockLoriotServer) createToken(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	m.nextToken++
+	token := fmt.Sprintf("vnoc%028d", m.nextToken)
+
+	app.tokens = append(app.tokens, token)
+	mockJSON(w, loriot.InlineResponse20015{Token: token})
+}
+
+func (m *mockLoriotServer) deleteToken(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	for i, token := range app.tokens {
+		if token == r.PathValue("TOKEN") {
+			app.tokens = append(app.tokens[:i], app.tokens[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	mockError(w, http.StatusNotFound, "token not found")
+}
+
+func (m *mockLoriotServer) createOutput(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.AppidOutputsBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	var osetup interface{} = map[string]interface{}{}
+	app.outputs = append(app.outputs, loriot.OutputInfo{
+		Id:     app.nextOutputId,
+		Output: body.Output,
+		Osetup: &osetup,
+	})
+	app.nextOutputId++
+
+	mockJSON(w, app.outputs)
+}
+
+func (m *mockLoriotServer) updateOutput(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.OutputsOutputidBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	i := app.findOutput(r.PathValue("OUTPUTID"))
+	if i < 0 {
+		mockError(w, http.StatusNotFound, "output not found")
+		return
+	}
+
+	if body.Output != "" {
+		app.outputs[i].Output = body.Output
+	}
+
+	if app.outputs[i].Output == appOutputHTTPPush && body.Osetup != nil {
+		if setup, ok := (*body.Osetup).(map[string]interface{}); ok {
+			if url, _ := setup["url"].(string); !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+				mockError(w, http.StatusBadRequest, "invalid output url")
+				return
+			}
+		}
+	}
+
+	app.outputs[i].Osetup = body.Osetup
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) deleteOutput(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	i := app.findOutput(r.PathValue("OUTPUTID"))
+	if i < 0 {
+		mockError(w, http.StatusNotFound, "output not found")
+		return
+	}
+
+	app.outputs = append(app.outputs[:i], app.outputs[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) listDevices(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	euis := make([]string, 0, len(app.devices))
+	for eui := range app.devices {
+		euis = append(euis, eui)
+	}
+	sort.Strings(euis)
+
+	// Only the title filter used by the provider is supported, which matches
+	// a regular expression ignoring case
+	titleFilter := regexp.MustCompile("")
+	if filter := r.URL.Query().Get("filter"); strings.HasPrefix(filter, "title~") {
+		var err error
+		if titleFilter, err = regexp.Compile("(?i)" + strings.TrimPrefix(filter, "title~")); err != nil {
+			mockError(w, http.StatusBadRequest, "invalid filter")
+			return
+		}
+	}
+
+	devices := make([]deviceResponse, 0, len(euis))
+	for _, eui := range euis {
+		device := app.devices[eui]
+		if !titleFilter.MatchString(device.Title) {
+			continue
+		}
+
+		devices = append(devices, deviceResponse{Device: device.Device, Title: device.Title, Description: device.Description})
+	}
+
+	page, perPage := mockPage(r, len(devices))
+	start, end := mockPageBounds(page, perPage, len(devices))
+
+	mockJSON(w, devicesListResponse{
+		Page:    float64(page),
+		PerPage: float64(perPage),
+		Total:   float64(len(devices)),
+		Devices: devices[start:end],
+	})
+}
+
+func (m *mockLoriotServer) createOTAADevice(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body deviceOtaaBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	device := &mockDevice{
+		Device: loriot.Device{
+			Id:       strings.ToUpper(body.Deveui),
+			Appeui:   strings.ToUpper(body.Appeui),
+			Devclass: body.Devclass,
+			Lorawan:  mockLoRaWANVersion(body.Lorawan),
+		},
+		Title:       body.Title,
+		Description: body.Description,
+		Appkey:      body.Appkey,
+		Nwkkey:      body.Nwkkey,
+	}
+
+	m.addDevice(w, app, device)
+}
+
+func (m *mockLoriotServer) createABPDevice(w http.ResponseWriter, r *http.Request, app *mockApp) {
+	var body loriot.DevicesAbpBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	device := &mockDevice{
+		Device: loriot.Device{
+			Id:       strings.ToUpper(body.Deveui),
+			Devaddr:  strings.ToUpper(body.Devaddr),
+			Devclass: "A",
+			Seqno:    body.Seqno,
+			Seqdn:    body.Seqdn,
+			Lorawan:  mockLoRaWANVersion(body.Lorawan),
+		},
+		Title:       body.Title,
+		Description: body.Description,
+		Nwkskey:     body.Nwkskey,
+		Appskey:     body.Appskey,
+	}
+
+	m.addDevice(w, app, device)
+}
+
+func (m *mockLoriotServer) addDevice(w http.ResponseWriter, app *mockApp, device *mockDevice) {
+	if _, ok := app.devices[device.Id]; ok {
+		mockError(w, http.StatusConflict, "device already exists")
+		return
+	}
+
+	if float64(len(app.devices)) >= app.DeviceLimit {
+		mockError(w, http.StatusUnprocessableEntity, "application device limit reached")
+		return
+	}
+
+	if device.Devaddr == "" {
+		device.Devaddr = fmt.Sprintf("%08X", len(app.devices)+1)
+	}
+
+	app.devices[device.Id] = device
+	mockJSON(w, device)
+}
+
+func (m *mockLoriotServer) getDevice(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	mockJSON(w, device)
+}
+
+func (m *mockLoriotServer) updateDevice(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	// Omitted fields are left unchanged, so an empty description is only
+	// distinguished from an omitted one by decoding it separately
+	var body struct {
+		loriot.DeviceDeveuiBody
+		Description *string `json:"description"`
+	}
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	if body.Title != "" {
+		device.Title = body.Title
+	}
+
+	if body.Devclass != "" {
+		device.Devclass = body.Devclass
+	}
+
+	if body.Lorawan != nil {
+		device.Lorawan = mockLoRaWANVersion(body.Lorawan)
+	}
+
+	if body.Description != nil {
+		device.Description = *body.Description
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) deleteDevice(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	delete(app.devices, device.Id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) updateDeviceAppKey(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	var body loriot.DeveuiAppkeyBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	device.Appkey = body.Appkey
+	mockJSON(w, loriot.InlineResponse20024{Appkey: device.Appkey})
+}
+
+func (m *mockLoriotServer) updateDeviceAppSKey(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	var body loriot.DeveuiAppskeyBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	device.Appskey = body.Appskey
+	mockJSON(w, loriot.InlineResponse20025{Appskey: device.Appskey})
+}
+
+func (m *mockLoriotServer) resetDeviceSeqno(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	device.Seqno = 0
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) resetDeviceSeqdn(w http.ResponseWriter, r *http.Request, app *mockApp, device *mockDevice) {
+	device.Seqdn = 0
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) createGateway(w http.ResponseWriter, r *http.Request) {
+	var body loriot.HexIdGatewaysBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	// Gateway EUIs are derived from the MAC address by inserting FFFE in the
+	// middle, unless a custom EUI is given.
+	mac := strings.ToUpper(strings.ReplaceAll(body.MAC, ":", ""))
+	eui := strings.ToUpper(body.CustomEUI)
+	if eui == "" && len(mac) == 12 {
+		eui = mac[:6] + "FFFE" + mac[6:]
+	}
+
+	if eui == "" {
+		mockError(w, http.StatusUnprocessableEntity, "invalid MAC address")
+		return
+	}
+
+	if _, ok := m.gateways[eui]; ok {
+		mockError(w, http.StatusConflict, "gateway already exists")
+		return
+	}
+
+	gateway := &loriot.InlineResponse20035{
+		Id:           eui,
+		EUI:          eui,
+		MAC:          body.MAC,
+		Title:        eui,
+		Base:         body.Base,
+		Model:        body.Model,
+		Bus:          body.Bus,
+		Concentrator: body.Concentrator,
+		Card:         body.Card,
+		Location:     body.Location,
+		RoamingId:    strings.ToUpper(r.PathValue("hexId")),
+		Region:       "EU868",
+	}
+
+	m.gateways[eui] = gateway
+
+	mockJSON(w, loriot.InlineResponse20031{
+		Id:    gateway.Id,
+		MAC:   gateway.MAC,
+		EUI:   gateway.EUI,
+		Base:  gateway.Base,
+		Model: gateway.Model,
+	})
+}
+
+func (m *mockLoriotServer) moveGateway(w http.ResponseWriter, r *http.Request) {
+	gateway, ok := m.gateways[strings.ToUpper(r.PathValue("GWEUI"))]
+	if !ok || gateway.RoamingId != strings.ToUpper(r.PathValue("hexId")) {
+		mockError(w, http.StatusNotFound, "gateway not found")
+		return
+	}
+
+	gateway.RoamingId = strings.ToUpper(r.PathValue("targetroamingid"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) getGateway(w http.ResponseWriter, r *http.Request) {
+	gateway, ok := m.gateways[strings.ToUpper(r.PathValue("GWEUI"))]
+	if !ok {
+		mockError(w, http.StatusNotFound, "gateway not found")
+		return
+	}
+
+	mockJSON(w, gateway)
+}
+
+func (m *mockLoriotServer) updateGateway(w http.ResponseWriter, r *http.Request) {
+	gateway, ok := m.gateways[strings.ToUpper(r.PathValue("GWEUI"))]
+	if !ok {
+		mockError(w, http.StatusNotFound, "gateway not found")
+		return
+	}
+
+	var body loriot.GatewayGweuiBody
+	if !mockDecode(w, r, &body) {
+		return
+	}
+
+	if body.Title != "" {
+		gateway.Title = body.Title
+	}
+
+	if body.Location != nil {
+		gateway.Location = body.Location
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockLoriotServer) deleteGateway(w http.ResponseWriter, r *http.Request) {
+	eui := strings.ToUpper(r.PathValue("GWEUI"))
+	if _, ok := m.gateways[eui]; !ok {
+		mockError(w, http.StatusNotFound, "gateway not found")
+		return
+	}
+
+	delete(m.gateways, eui)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// application returns the application as returned by the API, including its
+// outputs and device count.
+func (a *mockApp) application() loriot.Application {
+	app := a.Application
+	app.Devices = float64(len(a.devices))
+
+	app.Outputs = make([]interface{}, 0, len(a.outputs))
+	for _, output := range a.outputs {
+		app.Outputs = append(app.Outputs, output)
+	}
+
+	return app
+}
+
+func (a *mockApp) findOutput(outputId string) int {
+	id, err := strconv.ParseFloat(outputId, 64)
+	if err != nil {
+		return -1
+	}
+
+	for i, output := range a.outputs {
+		if output.Id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mockLoRaWANVersion(version *loriot.Model1nwkappAppiDdevicesabpLorawan) *loriot.DeviceProfileDeviceListedLorawan {
+	if version == nil {
+		return &loriot.DeviceProfileDeviceListedLorawan{Major: 1, Minor: 0, Revision: "2"}
+	}
+
+	return &loriot.DeviceProfileDeviceListedLorawan{
+		Major:    version.Major,
+		Minor:    version.Minor,
+		Revision: version.Revision,
+	}
+}
+
+// mockPage returns the requested page number and size, defaulting to a single
+// page holding every item.
+func mockPage(r *http.Request, total int) (int, int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(r.URL.Query().Get("perPage"))
+	if err != nil || perPage < 1 {
+		perPage = total
+	}
+
+	return page, perPage
+}
+
+func mockPageBounds(page, perPage, total int) (int, int) {
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	return start, end
+}
+
+func mockDecode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+
+	return true
+}
+
+func mockJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func mockError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": message,
+		"code":  status,
+	})
+}
@@ -0,0 +1,447 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MulticastGroupResource{}
+var _ resource.ResourceWithImportState = &MulticastGroupResource{}
+
+func NewMulticastGroupResource() resource.Resource {
+	return &MulticastGroupResource{}
+}
+
+// multicastGroupsPageSize is the number of multicast devices requested per
+// page when looking up a multicast group, the maximum allowed by the API.
+const multicastGroupsPageSize = 100
+
+var dataRateRegexp = regexp.MustCompile(`^(SF([5-9]|1[0-2])BW(125|250|500)|FSK[0-9]+)$`)
+
+// MulticastGroupResource defines the resource implementation.
+type MulticastGroupResource struct {
+	client *loriotClient
+}
+
+// MulticastGroupResourceModel describes the resource data model.
+type MulticastGroupResourceModel struct {
+	AppId            types.String  `tfsdk:"app_id"`
+	McastEUI         types.String  `tfsdk:"mcasteui"`
+	DevAddr          types.String  `tfsdk:"devaddr"`
+	NwkSKey          types.String  `tfsdk:"nwkskey"`
+	NwkSKeyWO        types.String  `tfsdk:"nwkskey_wo"`
+	NwkSKeyWOVersion types.Int64   `tfsdk:"nwkskey_wo_version"`
+	AppSKey          types.String  `tfsdk:"appskey"`
+	AppSKeyWO        types.String  `tfsdk:"appskey_wo"`
+	AppSKeyWOVersion types.Int64   `tfsdk:"appskey_wo_version"`
+	DataRate         types.String  `tfsdk:"data_rate"`
+	Frequency        types.Float64 `tfsdk:"frequency"`
+	Preload          types.Int64   `tfsdk:"preload"`
+	SeqDn            types.Int64   `tfsdk:"seqdn"`
+}
+
+func (r *MulticastGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_multicast_group"
+}
+
+func (r *MulticastGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Multicast group resource. Downlinks queued for the group are sent as class C downlinks by all gateways of the user, and count towards the `mcast_devices_limit` of the application",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "Application ID in hexadecimal format",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mcasteui": schema.StringAttribute{
+				MarkdownDescription: "Multicast group EUI in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(16),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"devaddr": schema.StringAttribute{
+				MarkdownDescription: "Multicast address (McAddr) in hexadecimal format",
+				Required:            true,
+				Validators: []validator.String{
+					hexStringValidator(8),
+				},
+			},
+			"nwkskey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 multicast network session key (McNwkSKey) in hexadecimal format. Exactly one of `nwkskey` or `nwkskey_wo` must be set",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("nwkskey_wo")),
+				},
+			},
+			"nwkskey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 multicast network session key (McNwkSKey) in hexadecimal format, which is not stored in the plan or state. It is only sent when the multicast group is created or `nwkskey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("nwkskey_wo_version")),
+				},
+			},
+			"nwkskey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `nwkskey_wo`, which must be changed to update the multicast network session key",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("nwkskey_wo")),
+				},
+			},
+			"appskey": schema.StringAttribute{
+				MarkdownDescription: "AES-128 multicast application session key (McAppSKey) in hexadecimal format. Exactly one of `appskey` or `appskey_wo` must be set",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("appskey_wo")),
+				},
+			},
+			"appskey_wo": schema.StringAttribute{
+				MarkdownDescription: "AES-128 multicast application session key (McAppSKey) in hexadecimal format, which is not stored in the plan or state. It is only sent when the multicast group is created or `appskey_wo_version` changes. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					hexStringValidator(32),
+					stringvalidator.AlsoRequires(path.MatchRoot("appskey_wo_version")),
+				},
+			},
+			"appskey_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `appskey_wo`, which must be changed to update the multicast application session key",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("appskey_wo")),
+				},
+			},
+			"data_rate": schema.StringAttribute{
+				MarkdownDescription: "Data rate of the multicast downlinks, such as `SF12BW125` or `FSK50000`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dataRateRegexp, "must be a LoRa data rate such as SF12BW125, or an FSK data rate such as FSK50000"),
+				},
+			},
+			"frequency": schema.Float64Attribute{
+				MarkdownDescription: "Frequency of the multicast downlinks in MHz",
+				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(137, 1020),
+				},
+			},
+			"preload": schema.Int64Attribute{
+				MarkdownDescription: "Time in advance to send multicast downlinks to the gateways, in milliseconds",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"seqdn": schema.Int64Attribute{
+				MarkdownDescription: "Downlink frame counter (McFCntDown) the multicast group is registered with. Changing this forces a new multicast group to be created",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MulticastGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*loriotClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MulticastGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config MulticastGroupResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := data.multicastBody(config)
+	body.Mcasteui = data.McastEUI.ValueString()
+	body.Seqdn = float64(data.SeqDn.ValueInt64())
+
+	opts := loriot.LoRaMulticastDeviceApi1NwkAppAPPIDMcastDevicePostOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	group, httpResp, err := r.client.LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDevicePost(ctx, data.AppId.ValueString(), &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to create Multicast Group", httpResp, err))
+		return
+	}
+
+	data.readMulticastGroup(group)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MulticastGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MulticastGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Fetching Multicast Group with EUI %s in App %s", data.McastEUI.ValueString(), data.AppId.ValueString()))
+
+	group, found, httpResp, err := r.findMulticastGroup(ctx, data.AppId.ValueString(), data.McastEUI.ValueString())
+	if err != nil {
+		if isNotFound(httpResp, err) {
+			tflog.Warn(ctx, fmt.Sprintf("App %s no longer exists, removing Multicast Group %s from state", data.AppId.ValueString(), data.McastEUI.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(clientError("Unable to read Multicast Group", httpResp, err))
+		return
+	}
+
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Multicast Group %s no longer exists in App %s, removing from state", data.McastEUI.ValueString(), data.AppId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.readMulticastGroup(group)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MulticastGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, data, config MulticastGroupResourceModel
+
+	// Read Terraform plan data into the model, and the write-only keys from
+	// the configuration
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appId := state.AppId.ValueString()
+	mcastEUI := state.McastEUI.ValueString()
+
+	// The downlink frame counter is left out, so it is not reset. Write-only
+	// keys are also left out unless their version changes, so they are not
+	// updated by changes to other settings.
+	body := data.multicastBody(config)
+
+	if !config.NwkSKeyWO.IsNull() && data.NwkSKeyWOVersion.Equal(state.NwkSKeyWOVersion) {
+		body.Nwkskey = ""
+	}
+
+	if !config.AppSKeyWO.IsNull() && data.AppSKeyWOVersion.Equal(state.AppSKeyWOVersion) {
+		body.Appskey = ""
+	}
+
+	opts := loriot.LoRaMulticastDeviceApi1NwkAppAPPIDMcastDeviceMCASTEUIPutOpts{
+		Body: optional.NewInterface(body),
+	}
+
+	httpResp, err := r.client.LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDeviceMCASTEUIPut(ctx, appId, mcastEUI, &opts)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to update Multicast Group", httpResp, err))
+		return
+	}
+
+	// Re-read the multicast group to ensure the most up-to-date version is returned
+	group, found, httpResp, err := r.findMulticastGroup(ctx, appId, mcastEUI)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError("Unable to read Multicast Group", httpResp, err))
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Resource Not Found",
+			fmt.Sprintf("Multicast Group %s was not found in App %s after it was updated", mcastEUI, appId),
+		)
+		return
+	}
+
+	data.readMulticastGroup(group)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MulticastGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MulticastGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Multicast Group may already have been deleted outside of Terraform
+	httpResp, err := r.client.LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDeviceMCASTEUIDelete(ctx, data.AppId.ValueString(), data.McastEUI.ValueString())
+	if err != nil && !isNotFound(httpResp, err) {
+		resp.Diagnostics.AddError(clientError("Unable to delete Multicast Group", httpResp, err))
+		return
+	}
+}
+
+func (r *MulticastGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/mcasteui. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mcasteui"), idParts[1])...)
+}
+
+// findMulticastGroup returns the multicast device with the EUI mcastEUI. The
+// API has no request for a single multicast device, so the multicast devices
+// of the application are listed until it is found.
+func (r *MulticastGroupResource) findMulticastGroup(ctx context.Context, appId string, mcastEUI string) (loriot.Mcastdev, bool, *http.Response, error) {
+	for page := float64(1); ; page++ {
+		opts := loriot.LoRaMulticastDeviceApi1NwkAppAPPIDMcastDeviceGetOpts{
+			Page:    optional.NewFloat64(page),
+			PerPage: optional.NewFloat64(multicastGroupsPageSize),
+		}
+
+		groups, httpResp, err := r.client.LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDeviceGet(ctx, appId, &opts)
+		if err != nil {
+			return loriot.Mcastdev{}, false, httpResp, err
+		}
+
+		for _, group := range groups {
+			if strings.EqualFold(group.Id, mcastEUI) {
+				return group, true, httpResp, nil
+			}
+		}
+
+		if len(groups) < multicastGroupsPageSize {
+			return loriot.Mcastdev{}, false, httpResp, nil
+		}
+	}
+}
+
+// multicastBody returns the settings of the multicast group as sent to the
+// create and update APIs, taking write-only keys from config.
+func (m *MulticastGroupResourceModel) multicastBody(config MulticastGroupResourceModel) loriot.Mcast {
+	return loriot.Mcast{
+		Devaddr: m.DevAddr.ValueString(),
+		Nwkskey: writeOnlyValue(m.NwkSKey, config.NwkSKeyWO).ValueString(),
+		Appskey: writeOnlyValue(m.AppSKey, config.AppSKeyWO).ValueString(),
+		Datr:    m.DataRate.ValueString(),
+		Freq:    m.Frequency.ValueFloat64(),
+		Preload: float64(m.Preload.ValueInt64()),
+	}
+}
+
+// readMulticastGroup copies the values returned by the API into the model.
+// The downlink frame counter is left as configured, as it advances with
+// traffic, and keys set through write-only attributes are not read, so they
+// are never stored in state.
+func (m *MulticastGroupResourceModel) readMulticastGroup(group loriot.Mcastdev) {
+	if !strings.EqualFold(m.McastEUI.ValueString(), group.Id) {
+		m.McastEUI = types.StringValue(group.Id)
+	}
+
+	if !strings.EqualFold(m.DevAddr.ValueString(), group.Devaddr) {
+		m.DevAddr = types.StringValue(group.Devaddr)
+	}
+
+	if m.NwkSKeyWOVersion.IsNull() && !strings.EqualFold(m.NwkSKey.ValueString(), group.Nwkskey) {
+		m.NwkSKey = types.StringValue(group.Nwkskey)
+	}
+
+	if m.AppSKeyWOVersion.IsNull() && !strings.EqualFold(m.AppSKey.ValueString(), group.Appskey) {
+		m.AppSKey = types.StringValue(group.Appskey)
+	}
+
+	m.DataRate = types.StringValue(group.Datr)
+	m.Frequency = types.Float64Value(group.Freq)
+	m.Preload = types.Int64Value(int64(group.Preload))
+
+	// Imported multicast groups have no configured counter, so start from the default
+	if m.SeqDn.IsNull() {
+		m.SeqDn = types.Int64Value(0)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMulticastGroupResource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Multicast device limit testing
+			{
+				Config:      server.providerConfig() + testAccMulticastGroupResourceConfig(0, "SF12BW125", 869.525),
+				ExpectError: regexp.MustCompile("multicast device limit reached"),
+			},
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccMulticastGroupResourceConfig(1, "SF12BW125", 869.525),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "mcasteui", "0011223344556677"),
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "devaddr", "01AB23CD"),
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "data_rate", "SF12BW125"),
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "frequency", "869.525"),
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "preload", "600000"),
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "seqdn", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "loriot_multicast_group.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccImportStateIdFunc("loriot_multicast_group.test", "app_id", "mcasteui"),
+				ImportStateVerifyIdentifierAttribute: "mcasteui",
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccMulticastGroupResourceConfig(1, "SF9BW125", 869.525),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "data_rate", "SF9BW125"),
+					resource.TestCheckResourceAttr("loriot_app.test", "mcast_devices_used", "1"),
+				),
+			},
+			// Deleted outside of Terraform testing
+			{
+				PreConfig: func() {
+					if _, err := server.client().LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDeviceMCASTEUIDelete(context.Background(), "BE010000", "0011223344556677"); err != nil {
+						t.Fatalf("unable to delete Multicast Group: %s", err)
+					}
+				},
+				Config:             server.providerConfig() + testAccMulticastGroupResourceConfig(1, "SF9BW125", 869.525),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccMulticastGroupResource_writeOnly(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	// testCheckKeys checks the keys the multicast group was registered or
+	// updated with, as they are not stored in state.
+	testCheckKeys := func(nwkSKey string, appSKey string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			opts := loriot.LoRaMulticastDeviceApi1NwkAppAPPIDMcastDeviceGetOpts{}
+
+			groups, _, err := server.client().LoRaMulticastDeviceApi.V1NwkAppAPPIDMcastDeviceGet(context.Background(), "BE010000", &opts)
+			if err != nil {
+				return err
+			}
+
+			if len(groups) != 1 || groups[0].Nwkskey != nwkSKey || groups[0].Appskey != appSKey {
+				return fmt.Errorf("expected multicast group with keys %s and %s, got %+v", nwkSKey, appSKey, groups)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: server.providerConfig() + testAccMulticastGroupResourceWriteOnlyConfig("SF12BW125", "F0E0D0C0B0A090807060504030201000", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("loriot_multicast_group.test", "nwkskey"),
+					resource.TestCheckNoResourceAttr("loriot_multicast_group.test", "nwkskey_wo"),
+					resource.TestCheckNoResourceAttr("loriot_multicast_group.test", "appskey"),
+					resource.TestCheckNoResourceAttr("loriot_multicast_group.test", "appskey_wo"),
+					testCheckKeys("000102030405060708090A0B0C0D0E0F", "F0E0D0C0B0A090807060504030201000"),
+				),
+			},
+			// The keys are not updated with other settings until their version changes
+			{
+				Config: server.providerConfig() + testAccMulticastGroupResourceWriteOnlyConfig("SF9BW125", "0F0E0D0C0B0A09080706050403020100", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "data_rate", "SF9BW125"),
+					testCheckKeys("000102030405060708090A0B0C0D0E0F", "F0E0D0C0B0A090807060504030201000"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: server.providerConfig() + testAccMulticastGroupResourceWriteOnlyConfig("SF9BW125", "0F0E0D0C0B0A09080706050403020100", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("loriot_multicast_group.test", "appskey_wo_version", "2"),
+					testCheckKeys("000102030405060708090A0B0C0D0E0F", "0F0E0D0C0B0A09080706050403020100"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccMulticastGroupResourceConfig(mcastDevicesLimit int, dataRate string, frequency float64) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = %[1]d
+}
+
+resource "loriot_multicast_group" "test" {
+  app_id    = loriot_app.test.app_id
+  mcasteui  = "0011223344556677"
+  devaddr   = "01AB23CD"
+  nwkskey   = "000102030405060708090A0B0C0D0E0F"
+  appskey   = "F0E0D0C0B0A090807060504030201000"
+  data_rate = %[2]q
+  frequency = %[3]g
+}
+`, mcastDevicesLimit, dataRate, frequency)
+}
+
+func testAccMulticastGroupResourceWriteOnlyConfig(dataRate string, appSKey string, appSKeyVersion int) string {
+	return fmt.Sprintf(`
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 1
+}
+
+resource "loriot_multicast_group" "test" {
+  app_id             = loriot_app.test.app_id
+  mcasteui           = "0011223344556677"
+  devaddr            = "01AB23CD"
+  nwkskey_wo         = "000102030405060708090A0B0C0D0E0F"
+  nwkskey_wo_version = 1
+  appskey_wo         = %[2]q
+  appskey_wo_version = %[3]d
+  data_rate          = %[1]q
+  frequency          = 869.525
+}
+`, dataRate, appSKey, appSKeyVersion)
+}
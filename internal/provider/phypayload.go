@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// LoRaWAN message types, the top three bits of the MHDR.
+const (
+	mTypeJoinRequest         = 0
+	mTypeJoinAccept          = 1
+	mTypeUnconfirmedDataUp   = 2
+	mTypeUnconfirmedDataDown = 3
+	mTypeConfirmedDataUp     = 4
+	mTypeConfirmedDataDown   = 5
+	mTypeRejoinRequest       = 6
+	mTypeProprietary         = 7
+)
+
+var mTypeNames = map[byte]string{
+	mTypeJoinRequest:         "JoinRequest",
+	mTypeJoinAccept:          "JoinAccept",
+	mTypeUnconfirmedDataUp:   "UnconfirmedDataUp",
+	mTypeUnconfirmedDataDown: "UnconfirmedDataDown",
+	mTypeConfirmedDataUp:     "ConfirmedDataUp",
+	mTypeConfirmedDataDown:   "ConfirmedDataDown",
+	mTypeRejoinRequest:       "RejoinRequest",
+	mTypeProprietary:         "Proprietary",
+}
+
+// micLength is the length in bytes of the message integrity code which ends
+// every frame.
+const micLength = 4
+
+// phyPayload is a LoRaWAN frame. The data frame fields are only set for data
+// messages, and the join fields for join requests.
+type phyPayload struct {
+	MType byte
+	Major byte
+	MIC   []byte
+
+	// Data frame fields
+	DevAddr    uint32
+	FCtrl      byte
+	FCnt       uint16
+	FOpts      []byte
+	FPort      *byte
+	FRMPayload []byte
+
+	// Join request fields
+	AppEUI   uint64
+	DevEUI   uint64
+	DevNonce uint16
+
+	// message is the frame without the MIC, which the MIC is computed over.
+	message []byte
+}
+
+// parsePHYPayload decodes the header and fields of a LoRaWAN 1.0.x frame.
+// Join accepts are encrypted, so only their header is decoded.
+func parsePHYPayload(data []byte) (phyPayload, error) {
+	var p phyPayload
+
+	if len(data) < 1+micLength {
+		return p, fmt.Errorf("frame of %d bytes is too short", len(data))
+	}
+
+	p.MType = data[0] >> 5
+	p.Major = data[0] & 0x03
+	p.message = data[:len(data)-micLength]
+	p.MIC = data[len(data)-micLength:]
+
+	switch {
+	case p.isDataFrame():
+		// MHDR, DevAddr, FCtrl and FCnt
+		if len(p.message) < 1+7 {
+			return p, fmt.Errorf("data frame of %d bytes is too short", len(data))
+		}
+
+		p.DevAddr = binary.LittleEndian.Uint32(p.message[1:5])
+		p.FCtrl = p.message[5]
+		p.FCnt = binary.LittleEndian.Uint16(p.message[6:8])
+
+		fOptsEnd := 8 + int(p.FCtrl&0x0F)
+		if len(p.message) < fOptsEnd {
+			return p, fmt.Errorf("data frame of %d bytes is too short for %d bytes of FOpts", len(data), fOptsEnd-8)
+		}
+
+		p.FOpts = p.message[8:fOptsEnd]
+
+		if len(p.message) > fOptsEnd {
+			fPort := p.message[fOptsEnd]
+			p.FPort = &fPort
+			p.FRMPayload = p.message[fOptsEnd+1:]
+		}
+	case p.MType == mTypeJoinRequest:
+		// MHDR, AppEUI, DevEUI and DevNonce
+		if len(p.message) != 1+8+8+2 {
+			return p, fmt.Errorf("join request of %d bytes must be 23 bytes", len(data))
+		}
+
+		p.AppEUI = binary.LittleEndian.Uint64(p.message[1:9])
+		p.DevEUI = binary.LittleEndian.Uint64(p.message[9:17])
+		p.DevNonce = binary.LittleEndian.Uint16(p.message[17:19])
+	}
+
+	return p, nil
+}
+
+// messageType returns the name of the message type.
+func (p phyPayload) messageType() string {
+	return mTypeNames[p.MType]
+}
+
+func (p phyPayload) isDataFrame() bool {
+	return p.MType >= mTypeUnconfirmedDataUp && p.MType <= mTypeConfirmedDataDown
+}
+
+func (p phyPayload) isUplink() bool {
+	return p.MType == mTypeUnconfirmedDataUp || p.MType == mTypeConfirmedDataUp
+}
+
+// direction is the direction byte of the blocks used to compute the MIC and
+// encrypt the payload, 0 for uplinks and 1 for downlinks.
+func (p phyPayload) direction() byte {
+	if p.isUplink() {
+		return 0
+	}
+
+	return 1
+}
+
+// block returns the B0 and A blocks of a data frame, which start with prefix
+// and end with suffix:
+//
+//	prefix | 0x00 * 4 | Dir | DevAddr | FCnt | 0x00 | suffix
+//
+// Only the 16 least significant bits of the frame counter are sent, so the
+// most significant bits are assumed to be zero.
+func (p phyPayload) block(prefix byte, suffix byte) []byte {
+	block := make([]byte, aes.BlockSize)
+	block[0] = prefix
+	block[5] = p.direction()
+	binary.LittleEndian.PutUint32(block[6:10], p.DevAddr)
+	binary.LittleEndian.PutUint32(block[10:14], uint32(p.FCnt))
+	block[15] = suffix
+
+	return block
+}
+
+// computeMIC returns the LoRaWAN 1.0.x MIC of a data frame:
+//
+//	cmac = aes128_cmac(NwkSKey, B0 | msg)
+//	MIC = cmac[0..3]
+func (p phyPayload) computeMIC(nwkSKey []byte) ([]byte, error) {
+	if !p.isDataFrame() {
+		return nil, errors.New("the MIC can only be verified for data frames")
+	}
+
+	if len(p.message) > 0xFF {
+		return nil, fmt.Errorf("frame of %d bytes is too long", len(p.message)+micLength)
+	}
+
+	b0 := p.block(0x49, byte(len(p.message)))
+
+	mac, err := aesCMAC(nwkSKey, append(b0, p.message...))
+	if err != nil {
+		return nil, err
+	}
+
+	return mac[:micLength], nil
+}
+
+// verifyMIC reports whether the MIC of a data frame was computed with nwkSKey.
+func (p phyPayload) verifyMIC(nwkSKey []byte) (bool, error) {
+	mic, err := p.computeMIC(nwkSKey)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(mic, p.MIC) == 1, nil
+}
+
+// decryptFRMPayload returns the decrypted FRMPayload of a data frame, which is
+// encrypted with the NwkSKey when FPort is 0 and the AppSKey otherwise. It
+// XORs the payload with the keystream of A blocks encrypted with key:
+//
+//	A_i = 0x01 | 0x00 * 4 | Dir | DevAddr | FCnt | 0x00 | i
+func (p phyPayload) decryptFRMPayload(key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, len(p.FRMPayload))
+	keystream := make([]byte, aes.BlockSize)
+
+	for i := 0; i < len(payload); i += aes.BlockSize {
+		block.Encrypt(keystream, p.block(0x01, byte(i/aes.BlockSize+1)))
+
+		for j := i; j < len(payload) && j < i+aes.BlockSize; j++ {
+			payload[j] = p.FRMPayload[j] ^ keystream[j-i]
+		}
+	}
+
+	return payload, nil
+}
+
+// aesCMAC returns the AES-CMAC of message, as defined by RFC 4493.
+func aesCMAC(key []byte, message []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the subkeys K1 and K2 from L = AES(K, 0)
+	k1 := make([]byte, aes.BlockSize)
+	block.Encrypt(k1, k1)
+	k1 = cmacShift(k1)
+	k2 := cmacShift(k1)
+
+	blocks := (len(message) + aes.BlockSize - 1) / aes.BlockSize
+	complete := blocks > 0 && len(message)%aes.BlockSize == 0
+
+	if blocks == 0 {
+		blocks = 1
+	}
+
+	// The last block is XORed with K1 if it is complete, or padded and XORed
+	// with K2 otherwise
+	last := make([]byte, aes.BlockSize)
+	copy(last, message[(blocks-1)*aes.BlockSize:])
+
+	subkey := k1
+	if !complete {
+		last[len(message)-(blocks-1)*aes.BlockSize] = 0x80
+		subkey = k2
+	}
+
+	for i := range last {
+		last[i] ^= subkey[i]
+	}
+
+	mac := make([]byte, aes.BlockSize)
+
+	for i := 0; i < blocks; i++ {
+		input := last
+		if i < blocks-1 {
+			input = message[i*aes.BlockSize : (i+1)*aes.BlockSize]
+		}
+
+		for j := range mac {
+			mac[j] ^= input[j]
+		}
+
+		block.Encrypt(mac, mac)
+	}
+
+	return mac, nil
+}
+
+// cmacShift shifts a block left by one bit, XORing the result with the
+// constant Rb if the most significant bit was set.
+func cmacShift(in []byte) []byte {
+	out := make([]byte, len(in))
+
+	for i := range in {
+		out[i] = in[i] << 1
+		if i+1 < len(in) {
+			out[i] |= in[i+1] >> 7
+		}
+	}
+
+	if in[0]&0x80 != 0 {
+		out[len(out)-1] ^= 0x87
+	}
+
+	return out
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// testNwkSKey and testAppSKey are the session keys of the test frames. The
+// first frame is the example used by the lora-packet library, and the second
+// was built independently with openssl.
+const (
+	testNwkSKey = "44024241ED4CE9A68C6A8BC055233FD3"
+	testAppSKey = "EC925802AE430CA77FD3DD73CB2CC588"
+)
+
+func TestAESCMAC(t *testing.T) {
+	// RFC 4493 section 4
+	key, _ := hex.DecodeString("2B7E151628AED2A6ABF7158809CF4F3C")
+	message, _ := hex.DecodeString("6BC1BEE22E409F96E93D7E117393172AAE2D8A571E03AC9C9EB76FAC45AF8E5130C81C46A35CE411E5FBC1191A0A52EFF69F2445DF4F9B17AD2B417BE66C3710")
+
+	testCases := map[string]struct {
+		length int
+		want   string
+	}{
+		"empty":       {length: 0, want: "BB1D6929E95937287FA37D129B756746"},
+		"one-block":   {length: 16, want: "070A16B46B4D4144F79BDD9DD04A287C"},
+		"partial":     {length: 40, want: "DFA66747DE9AE63030CA32611497C827"},
+		"four-blocks": {length: 64, want: "51F0BEBF7E3B9D92FC49741779363CFE"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := aesCMAC(key, message[:testCase.length])
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if hexValue(got) != testCase.want {
+				t.Errorf("got %s, want %s", hexValue(got), testCase.want)
+			}
+		})
+	}
+}
+
+func TestParsePHYPayloadDataFrame(t *testing.T) {
+	nwkSKey, _ := parseAESKey(testNwkSKey)
+	appSKey, _ := parseAESKey(testAppSKey)
+
+	testCases := map[string]struct {
+		frame   string
+		devAddr uint32
+		fCnt    uint16
+		fPort   byte
+		payload string
+	}{
+		"single-block": {
+			frame:   "40F17DBE4900020001954378762B11FF0D",
+			devAddr: 0x49BE7DF1,
+			fCnt:    2,
+			fPort:   1,
+			payload: "test",
+		},
+		"multiple-blocks": {
+			frame:   "40F17DBE49000300024DD47AD68A1802341CC34DBA25368893E59BAC92B59AAFB1",
+			devAddr: 0x49BE7DF1,
+			fCnt:    3,
+			fPort:   2,
+			payload: "hello loriot network",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(testCase.frame)
+
+			p, err := parsePHYPayload(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if p.messageType() != "UnconfirmedDataUp" || p.DevAddr != testCase.devAddr || p.FCnt != testCase.fCnt {
+				t.Errorf("got %s from %08X with FCnt %d", p.messageType(), p.DevAddr, p.FCnt)
+			}
+
+			if p.FPort == nil || *p.FPort != testCase.fPort {
+				t.Fatalf("got FPort %v, want %d", p.FPort, testCase.fPort)
+			}
+
+			valid, err := p.verifyMIC(nwkSKey)
+			if err != nil || !valid {
+				t.Errorf("got MIC valid %t, error %v", valid, err)
+			}
+
+			if valid, _ := p.verifyMIC(appSKey); valid {
+				t.Error("MIC valid with the wrong key")
+			}
+
+			payload, err := p.decryptFRMPayload(appSKey)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(payload) != testCase.payload {
+				t.Errorf("got payload %q, want %q", payload, testCase.payload)
+			}
+		})
+	}
+}
+
+func TestParsePHYPayloadFOpts(t *testing.T) {
+	// Unconfirmed downlink with ACK set, three bytes of FOpts and no FPort
+	data, _ := hex.DecodeString("60F17DBE4923050002030401020304")
+
+	p, err := parsePHYPayload(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if p.messageType() != "UnconfirmedDataDown" || p.isUplink() {
+		t.Errorf("got %s", p.messageType())
+	}
+
+	if p.FCtrl&0x20 == 0 || p.FCnt != 5 || hexValue(p.FOpts) != "020304" {
+		t.Errorf("got FCtrl %02X, FCnt %d, FOpts %X", p.FCtrl, p.FCnt, p.FOpts)
+	}
+
+	if p.FPort != nil || len(p.FRMPayload) != 0 {
+		t.Errorf("got FPort %v and FRMPayload %X, want none", p.FPort, p.FRMPayload)
+	}
+}
+
+func TestParsePHYPayloadJoinRequest(t *testing.T) {
+	data, _ := hex.DecodeString("00000000D07ED5B3707766554433221100CDAB01020304")
+
+	p, err := parsePHYPayload(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if p.messageType() != "JoinRequest" {
+		t.Errorf("got %s", p.messageType())
+	}
+
+	if formatEUI(p.AppEUI) != "70B3D57ED0000000" || formatEUI(p.DevEUI) != "0011223344556677" || p.DevNonce != 0xABCD {
+		t.Errorf("got AppEUI %016X, DevEUI %016X, DevNonce %04X", p.AppEUI, p.DevEUI, p.DevNonce)
+	}
+
+	if hexValue(p.MIC) != "01020304" {
+		t.Errorf("got MIC %X", p.MIC)
+	}
+
+	if _, err := p.computeMIC(nil); err == nil {
+		t.Error("expected error computing the MIC of a join request")
+	}
+}
+
+func TestParsePHYPayloadErrors(t *testing.T) {
+	testCases := map[string]struct {
+		frame   string
+		wantErr string
+	}{
+		"empty":              {frame: "", wantErr: "too short"},
+		"data-frame-header":  {frame: "40F17DBE4900", wantErr: "data frame of 6 bytes is too short"},
+		"fopts-length":       {frame: "40F17DBE490F020001020304", wantErr: "too short for 15 bytes of FOpts"},
+		"join-request-short": {frame: "00000000D07ED5B37001020304", wantErr: "must be 23 bytes"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(testCase.frame)
+
+			_, err := parsePHYPayload(data)
+			if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+				t.Errorf("got error %v, want %q", err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -4,22 +4,41 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure LoriotProvider satisfies various provider interfaces.
 var _ provider.Provider = &LoriotProvider{}
 var _ provider.ProviderWithFunctions = &LoriotProvider{}
+var _ provider.ProviderWithConfigValidators = &LoriotProvider{}
+var _ provider.ProviderWithEphemeralResources = &LoriotProvider{}
 
 // LoriotProvider defines the provider implementation.
 type LoriotProvider struct {
@@ -31,8 +50,17 @@ type LoriotProvider struct {
 
 // LoriotProviderModel describes the provider data model.
 type LoriotProviderModel struct {
-	Host   types.String `tfsdk:"host"`
-	APIKey types.String `tfsdk:"key"`
+	Host            types.String  `tfsdk:"host"`
+	Region          types.String  `tfsdk:"region"`
+	CABundleFile    types.String  `tfsdk:"ca_bundle_file"`
+	Insecure        types.Bool    `tfsdk:"insecure_skip_verify"`
+	APIKey          types.String  `tfsdk:"key"`
+	AppToken        types.String  `tfsdk:"app_token"`
+	Profile         types.String  `tfsdk:"profile"`
+	CredentialsFile types.String  `tfsdk:"credentials_file"`
+	MaxRetries      types.Int64   `tfsdk:"max_retries"`
+	Timeout         types.Int64   `tfsdk:"timeout"`
+	RateLimit       types.Float64 `tfsdk:"rate_limit"`
 }
 
 func (p *LoriotProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,20 +72,85 @@ func (p *LoriotProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Hostname of the Loriot instance",
+				MarkdownDescription: "Hostname or https URL of the Loriot instance, such as `https://eu1.loriot.io`. May also be set with the `LORIOT_HOST` environment variable",
 				Required:            false,
 				Optional:            true,
+				Validators: []validator.String{
+					hostValidator{},
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Public Loriot cluster to connect to instead of setting `host`, such as `eu1` for `https://eu1.loriot.io`. May also be set with the `LORIOT_REGION` environment variable",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(loriotRegions...),
+				},
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM file of certificate authorities trusted in addition to the system roots, for private instances. May also be set with the `LORIOT_CA_BUNDLE_FILE` environment variable",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the certificate of the instance. Only intended for testing private instances, as it allows the connection to be intercepted. May also be set with the `LORIOT_INSECURE_SKIP_VERIFY` environment variable",
+				Optional:            true,
 			},
 			"key": schema.StringAttribute{
-				MarkdownDescription: "API Key used to authenticate with the instance",
+				MarkdownDescription: "API Key used to authenticate with the instance. May also be set with the `LORIOT_API_KEY` environment variable",
 				Required:            false,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"app_token": schema.StringAttribute{
+				MarkdownDescription: "Application token used to authenticate when no API key is set. Application tokens only allow reading data sources of their application. May also be set with the `LORIOT_APP_TOKEN` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Profile of the credentials file to read settings from. May also be set with the `LORIOT_PROFILE` environment variable. Defaults to `%s`", defaultProfile),
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Path of the credentials file holding the `host`, `region`, `key` and `app_token` settings of named profiles. May also be set with the `LORIOT_CREDENTIALS_FILE` environment variable. Defaults to `~/%s`", defaultCredentialsFile),
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request is retried when rate limited or failing with a server error. Defaults to %d", defaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Time in seconds allowed for each API request, including retries. Defaults to %.0f", defaultTimeout.Seconds()),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rate_limit": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests per second made to the Loriot instance. Unlimited unless set",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
 
+func (p *LoriotProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("host"),
+			path.MatchRoot("region"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("key"),
+			path.MatchRoot("app_token"),
+		),
+	}
+}
+
 func (p *LoriotProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data LoriotProviderModel
 
@@ -78,28 +171,97 @@ func (p *LoriotProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
-	if data.APIKey.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("key"),
-			"Unknown Loriot API Key",
-			"The provider cannot create the Loriot API client as there is an unknown configuration value for the Loriot API key "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the LORIOT_API_KEY environment variable.",
-		)
+	unknownSettings := []struct {
+		name  string
+		value attr.Value
+		hint  string
+	}{
+		{"region", data.Region, "use an environment variable or credentials file"},
+		{"ca_bundle_file", data.CABundleFile, "use an environment variable or credentials file"},
+		{"insecure_skip_verify", data.Insecure, "use the LORIOT_INSECURE_SKIP_VERIFY environment variable"},
+		{"key", data.APIKey, "use an environment variable or credentials file"},
+		{"app_token", data.AppToken, "use an environment variable or credentials file"},
+		{"profile", data.Profile, "use an environment variable or credentials file"},
+		{"credentials_file", data.CredentialsFile, "use an environment variable or credentials file"},
+		{"max_retries", data.MaxRetries, "remove it to use the default"},
+		{"timeout", data.Timeout, "remove it to use the default"},
+		{"rate_limit", data.RateLimit, "remove it to use the default"},
+	}
+
+	for _, setting := range unknownSettings {
+		if setting.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				"Unknown Loriot Setting",
+				fmt.Sprintf("The provider cannot create the Loriot API client as there is an unknown configuration value for %s. ", setting.name)+
+					fmt.Sprintf("Either target apply the source of the value first, set the value statically in the configuration, or %s.", setting.hint),
+			)
+		}
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
-	host := os.Getenv("LORIOT_HOST")
-	key := os.Getenv("LORIOT_API_KEY")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The credentials file to read settings from is set by the Terraform
+	// configuration or environment variables
+	profile := stringSetting(data.Profile, "LORIOT_PROFILE", defaultProfile)
+	credentialsFile := stringSetting(data.CredentialsFile, "LORIOT_CREDENTIALS_FILE", "")
+
+	// The default credentials file and profile are optional, but one which
+	// was chosen must exist
+	explicitFile := credentialsFile != ""
+	explicitProfile := profile != defaultProfile
+
+	if !explicitFile {
+		if defaultPath, err := defaultCredentialsPath(); err == nil {
+			credentialsFile = defaultPath
+		}
+	}
+
+	var fileCredentials loriotCredentials
+
+	if credentialsFile != "" {
+		var err error
+
+		fileCredentials, err = readCredentialsFile(credentialsFile, profile)
+
+		optional := !explicitFile && !explicitProfile && (errors.Is(err, os.ErrNotExist) || errors.Is(err, errProfileNotFound))
+		if err != nil && !optional {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_file"),
+				"Unable to Read Loriot Credentials File",
+				fmt.Sprintf("The provider cannot read profile %q of the credentials file: %s", profile, err),
+			)
+			return
+		}
+	}
 
-	if !data.Host.IsNull() {
-		host = data.Host.ValueString()
+	// The host is read from the Terraform configuration, then environment
+	// variables, then the credentials file. Each may set it by region.
+	host := hostSetting(data, fileCredentials)
+
+	// Credentials are taken together from the first of those which sets any,
+	// so that a key in the environment does not mix with a configured token.
+	credentials := loriotCredentials{
+		APIKey:   data.APIKey.ValueString(),
+		AppToken: data.AppToken.ValueString(),
+	}
+
+	if !credentials.hasAuthentication() {
+		credentials = loriotCredentials{
+			APIKey:   os.Getenv("LORIOT_API_KEY"),
+			AppToken: os.Getenv("LORIOT_APP_TOKEN"),
+		}
 	}
 
-	if !data.APIKey.IsNull() {
-		key = data.APIKey.ValueString()
+	if !credentials.hasAuthentication() {
+		credentials = fileCredentials
 	}
 
+	key := credentials.APIKey
+	appToken := credentials.AppToken
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -108,41 +270,212 @@ func (p *LoriotProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			path.Root("host"),
 			"Missing Loriot instance Host",
 			"The provider cannot create the Loriot API client as there is a missing or empty value for the Loriot instance host. "+
-				"Set the host value in the configuration or use the LORIOT_HOST environment variable. "+
+				"Set the host or region value in the configuration, use the LORIOT_HOST or LORIOT_REGION environment variables, or set either in the credentials file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if key == "" {
+	if !credentials.hasAuthentication() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("key"),
-			"Missing Loriot API key",
-			"The provider cannot create the Loriot API client as there is a missing or empty value for the Loriot API key. "+
-				"Set the apikey value in the configuration or use the LORIOT_API_KEY environment variable. "+
+			"Missing Loriot Credentials",
+			"The provider cannot create the Loriot API client as there are no credentials for the Loriot instance. "+
+				"Set an API key or an application token in the configuration, "+
+				"use the LORIOT_API_KEY or LORIOT_APP_TOKEN environment variables, "+
+				"or set them in the credentials file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	if host != "" {
+		baseURL, err := normalizeHost(host)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Invalid Loriot instance Host",
+				fmt.Sprintf("The provider cannot create the Loriot API client as the host %q is not valid: %s", host, err),
+			)
+		}
+
+		host = baseURL
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cfg := loriot.NewConfiguration()
+	insecure := data.Insecure.ValueBool()
+	if data.Insecure.IsNull() {
+		insecure, _ = strconv.ParseBool(os.Getenv("LORIOT_INSECURE_SKIP_VERIFY"))
+	}
 
+	if insecure {
+		tflog.Warn(ctx, "Certificate verification of the Loriot instance is disabled")
+	}
+
+	transport, err := newHTTPTransport(stringSetting(data.CABundleFile, "LORIOT_CA_BUNDLE_FILE", ""), insecure)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle_file"),
+			"Unable to Read Loriot CA Bundle",
+			fmt.Sprintf("The provider cannot read the certificate authorities trusted for the Loriot instance: %s", err),
+		)
+		return
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+
+	timeout := defaultTimeout
+	if !data.Timeout.IsNull() {
+		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	}
+
+	httpClient := &http.Client{
+		Transport: newRetryTransport(transport, int(maxRetries), data.RateLimit.ValueFloat64()),
+		Timeout:   timeout,
+	}
+
+	cfg := loriot.NewConfiguration()
 	cfg.BasePath = host
-	cfg.AddDefaultHeader("Authorization", "Bearer "+key)
 
-	client := loriot.NewAPIClient(cfg)
+	// Credentials are used in order of precedence: an API key, then an
+	// application token
+	switch {
+	case key != "":
+		cfg.AddDefaultHeader("Authorization", "Bearer "+key)
+	default:
+		tflog.Info(ctx, "Authenticating with an application token, only data sources can be read")
+
+		cfg.AddDefaultHeader("Authorization", "Bearer "+appToken)
+		httpClient.Transport = &readOnlyTransport{next: httpClient.Transport}
+	}
+
+	cfg.HTTPClient = httpClient
+
+	client := &loriotClient{
+		APIClient: loriot.NewAPIClient(cfg),
+		cfg:       cfg,
+	}
 
 	// Example client configuration for data sources and resources
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// loriotClient is the client passed to data sources and resources. It embeds
+// the generated API client, and keeps the configuration the client was
+// created with for the requests the generated client cannot make.
+type loriotClient struct {
+	*loriot.APIClient
+	cfg *loriot.Configuration
+}
+
+// do makes a request the generated client cannot make to the API path p with
+// the configured HTTP client. The body is encoded as JSON if it is not nil,
+// and the response is decoded into result if it is not nil.
+func (c *loriotClient) do(ctx context.Context, method string, p string, body interface{}, result interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.cfg.BasePath, "/")+p, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range c.cfg.DefaultHeader {
+		req.Header.Set(name, value)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+
+	httpResp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return httpResp, err
+	}
+
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return httpResp, err
+	}
+
+	if httpResp.StatusCode >= 300 {
+		return httpResp, responseError{status: httpResp.Status, body: respBody}
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return httpResp, err
+		}
+	}
+
+	return httpResp, nil
+}
+
+// stringSetting returns the configured value of a setting, falling back to the
+// environment variable env and then to fallback.
+func stringSetting(value types.String, env string, fallback string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+
+	if envValue := os.Getenv(env); envValue != "" {
+		return envValue
+	}
+
+	return fallback
+}
+
+// hostSetting returns the host set in the configuration, environment
+// variables or credentials file, in that order. Each may set a region
+// instead of a host.
+func hostSetting(data LoriotProviderModel, credentials loriotCredentials) string {
+	switch {
+	case !data.Host.IsNull():
+		return data.Host.ValueString()
+	case !data.Region.IsNull():
+		return regionURL(data.Region.ValueString())
+	case os.Getenv("LORIOT_HOST") != "":
+		return os.Getenv("LORIOT_HOST")
+	case os.Getenv("LORIOT_REGION") != "":
+		return regionURL(os.Getenv("LORIOT_REGION"))
+	case credentials.Host != "":
+		return credentials.Host
+	case credentials.Region != "":
+		return regionURL(credentials.Region)
+	}
+
+	return ""
 }
 
 func (p *LoriotProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewExampleResource,
 		NewAppResource,
+		NewAppOutputResource,
+		NewAppTokenResource,
+		NewDeviceResource,
+		NewDeviceABPResource,
+		NewGatewayResource,
+		NewMulticastGroupResource,
 	}
 }
 
@@ -152,13 +485,29 @@ func (p *LoriotProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewUserDataSource,
 		NewUserUsageDataSource,
 		NewAppDataSource,
+		NewAppsDataSource,
 		NewAppTokenDataSource,
+		NewDevicesDataSource,
+		NewGatewayDataSource,
+	}
+}
+
+func (p *LoriotProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAppTokenEphemeralResource,
 	}
 }
 
 func (p *LoriotProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		NewExampleFunction,
+		NewEUINormalizeFunction,
+		NewEUIToDecimalFunction,
+		NewDecimalToEUIFunction,
+		NewAppIdToDecimalFunction,
+		NewAESKeyValidFunction,
+		NewDeriveSessionKeysFunction,
+		NewDeriveJoinServerKeysFunction,
+		NewDecodePHYPayloadFunction,
 	}
 }
 
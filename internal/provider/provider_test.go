@@ -4,10 +4,21 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
+	"bitbucket.org/msabbott/loriot-go-client"
+	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const (
@@ -28,3 +39,174 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccImportStateIdFunc returns the import identifier of a resource, made
+// of the given attributes separated by slashes.
+func testAccImportStateIdFunc(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		parts := make([]string, 0, len(attributes))
+		for _, attribute := range attributes {
+			parts = append(parts, rs.Primary.Attributes[attribute])
+		}
+
+		return strings.Join(parts, "/"), nil
+	}
+}
+
+func TestAccProviderCredentialsFile(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	// Environment variables take precedence over the credentials file
+	for _, name := range []string{"LORIOT_HOST", "LORIOT_REGION", "LORIOT_API_KEY", "LORIOT_APP_TOKEN"} {
+		t.Setenv(name, "")
+	}
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	content := fmt.Sprintf("[default]\nhost = http://localhost:1\n\n[mock]\nhost = %s\nkey  = %s\n", server.URL, mockAPIKey)
+
+	if err := os.WriteFile(credentialsFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write credentials file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Failing steps come first, as the destroy run after the last step
+			// uses its configuration
+			{
+				Config: fmt.Sprintf(`
+provider "loriot" {
+  credentials_file = %[1]q
+  profile          = "missing"
+}
+`, credentialsFile) + testAccUserDataSourceConfig,
+				ExpectError: regexp.MustCompile(`Unable to Read Loriot Credentials File`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "loriot" {
+  credentials_file = %[1]q
+  profile          = "mock"
+}
+`, credentialsFile) + testAccUserDataSourceConfig,
+				Check: resource.TestCheckResourceAttr("data.loriot_user.test", "email", "user@example.com"),
+			},
+		},
+	})
+}
+
+func TestAccProviderAppToken(t *testing.T) {
+	server := newMockLoriotServer(t)
+	client := server.client()
+
+	opts := loriot.LoRaApplicationApi1NwkAppsPostOpts{
+		Body: optional.NewInterface(appCreateBody{
+			NwkAppsBody: loriot.NwkAppsBody{Title: "test", Capacity: 10, Visibility: "private"},
+		}),
+	}
+
+	app, _, err := client.LoRaApplicationApi.V1NwkAppsPost(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("unable to create App: %s", err)
+	}
+
+	token, _, err := client.LoRaApplicationApi.V1NwkAppAPPIDTokenPost(context.Background(), map[string]interface{}{}, app.AppHexId)
+	if err != nil {
+		t.Fatalf("unable to create App Token: %s", err)
+	}
+
+	providerConfig := fmt.Sprintf(`
+provider "loriot" {
+  host      = %[1]q
+  app_token = %[2]q
+}
+`, server.URL, token.Token)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Data sources of the application can be read
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "loriot_app" "test" {
+  app_id = %[1]q
+}
+`, app.AppHexId),
+				Check: resource.TestCheckResourceAttr("data.loriot_app.test", "name", "test"),
+			},
+			// Resources cannot be managed
+			{
+				Config: providerConfig + `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`only\s+allows\s+reading\s+data\s+sources`),
+			},
+		},
+	})
+}
+
+func TestAccProviderHost(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "loriot" {
+  host = "http://eu1.loriot.io"
+  key  = "key"
+}
+` + testAccUserDataSourceConfig,
+				ExpectError: regexp.MustCompile(`must\s+use\s+https`),
+			},
+			{
+				Config: `
+provider "loriot" {
+  host   = "https://eu1.loriot.io"
+  region = "eu1"
+  key    = "key"
+}
+` + testAccUserDataSourceConfig,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccProviderUnknownSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// terraform_data was added in Terraform 1.4
+			tfversion.SkipBelow(tfversion.Version1_4_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terraform_data" "timeout" {
+  input = 30
+}
+
+provider "loriot" {
+  host    = "https://eu1.loriot.io"
+  key     = "key"
+  timeout = terraform_data.timeout.output
+}
+` + testAccUserDataSourceConfig,
+				ExpectError: regexp.MustCompile(`unknown\s+configuration\s+value\s+for\s+timeout`),
+			},
+		},
+	})
+}
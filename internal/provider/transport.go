@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultMaxRetries is the number of times a failed request is retried
+	// when the provider does not configure max_retries.
+	defaultMaxRetries = 3
+
+	// defaultTimeout is the time allowed for a request, including retries,
+	// when the provider does not configure timeout.
+	defaultTimeout = 5 * time.Minute
+
+	// defaultMinBackoff and defaultMaxBackoff bound the delay between retries.
+	defaultMinBackoff = 1 * time.Second
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// retryTransport is an http.RoundTripper which limits the rate of requests
+// made to each host and retries requests which were rate limited or failed
+// with a server error, backing off exponentially between attempts.
+//
+// Requests rejected with 429 Too Many Requests or 503 Service Unavailable
+// were not processed, so are retried whatever their method. Other server
+// errors and connection failures are only retried for idempotent methods, to
+// avoid registering the same application or device twice.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	// rateLimit is the maximum number of requests per second made to each
+	// host, or zero for no limit.
+	rateLimit float64
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, rateLimit float64) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		rateLimit:  rateLimit,
+		nextSlot:   map[string]time.Time{},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The body has been consumed by the previous attempt
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("unable to retry %s %s: request body cannot be rewound", req.Method, req.URL)
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		if err := t.wait(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Request %s %s failed, retrying in %s: %s", req.Method, req.URL.Path, delay, err))
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("Request %s %s returned %s, retrying in %s", req.Method, req.URL.Path, resp.Status, delay))
+
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request which returned resp or err should be
+// attempted again.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// The request was cancelled or timed out, rather than failing
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		return isIdempotent(req.Method)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+// backoff returns the delay before the next attempt. The delay requested by
+// the server through Retry-After is used if present, up to the maximum
+// backoff, otherwise it doubles with each attempt, with jitter so that
+// concurrent requests spread out.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After"), t.maxBackoff); ok {
+			return delay
+		}
+	}
+
+	delay := t.minBackoff << attempt
+	if delay <= 0 || delay > t.maxBackoff {
+		delay = t.maxBackoff
+	}
+
+	// Use a random delay between half and all of the backoff
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// wait blocks until the rate limit allows a request to the host of req.
+func (t *retryTransport) wait(req *http.Request) error {
+	if t.rateLimit <= 0 {
+		return nil
+	}
+
+	interval := time.Duration(float64(time.Second) / t.rateLimit)
+
+	t.mu.Lock()
+	now := time.Now()
+	slot := t.nextSlot[req.URL.Host]
+	if slot.Before(now) {
+		slot = now
+	}
+	t.nextSlot[req.URL.Host] = slot.Add(interval)
+	t.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, limiting the delay to limit so that a
+// misbehaving server cannot stall the run.
+func retryAfter(value string, limit time.Duration) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return min(time.Duration(seconds)*time.Second, limit), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return min(delay, limit), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// errReadOnly is returned for requests which are not allowed by readOnlyTransport.
+var errReadOnly = errors.New("the provider is authenticated with an application token, which only allows reading data sources. Configure an API key to manage resources")
+
+// readOnlyTransport is an http.RoundTripper which rejects requests that could
+// make changes, used when the provider authenticates with an application
+// token.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	return nil, errReadOnly
+}
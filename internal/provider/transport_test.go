@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	testCases := map[string]struct {
+		method       string
+		statuses     []int
+		retryAfter   string
+		maxRetries   int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		"success": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		"too-many-requests": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		"retry-after": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "0",
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"server-error-idempotent": {
+			method:       http.MethodPut,
+			statuses:     []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		"server-error-not-idempotent": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		"client-error": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusNotFound, http.StatusOK},
+			maxRetries:   3,
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+		"retries-exhausted": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			maxRetries:   2,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+		"retries-disabled": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:   0,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+
+				// Every attempt must carry the full request body
+				if body, _ := io.ReadAll(r.Body); string(body) != "body" {
+					t.Errorf("attempt %d: unexpected body %q", attempt, body)
+				}
+
+				if testCase.retryAfter != "" {
+					w.Header().Set("Retry-After", testCase.retryAfter)
+				}
+
+				w.WriteHeader(testCase.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			transport := newRetryTransport(http.DefaultTransport, testCase.maxRetries, 0)
+			transport.minBackoff = time.Millisecond
+			transport.maxBackoff = 10 * time.Millisecond
+
+			req, err := http.NewRequest(testCase.method, server.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != testCase.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, testCase.wantStatus)
+			}
+
+			if attempts != testCase.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, testCase.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, 0, 20)
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	// The first request is sent immediately, the other four 50ms apart
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 requests at 20 per second took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		"empty":    {value: "", wantOk: false},
+		"seconds":  {value: "5", want: 5 * time.Second, wantOk: true},
+		"negative": {value: "-1", wantOk: false},
+		"past":     {value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOk: true},
+		"invalid":  {value: "soon", wantOk: false},
+		"capped":   {value: "7200", want: defaultMaxBackoff, wantOk: true},
+		"far-date": {value: time.Now().Add(2 * time.Hour).UTC().Format(http.TimeFormat), want: defaultMaxBackoff, wantOk: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := retryAfter(testCase.value, defaultMaxBackoff)
+
+			if ok != testCase.wantOk || got != testCase.want {
+				t.Errorf("retryAfter(%q) = %s, %t, want %s, %t", testCase.value, got, ok, testCase.want, testCase.wantOk)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,7 +22,7 @@ func NewUserDataSource() datasource.DataSource {
 
 // UserDataSource defines the data source implementation.
 type UserDataSource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // UserDataSourceModel describes the data source data model.
@@ -120,12 +119,12 @@ func (d *UserDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *loriot.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -146,9 +145,9 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	user, _, err := d.client.UserApi.V1NwkUserGet(ctx)
+	user, httpResp, err := d.client.UserApi.V1NwkUserGet(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read User, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read User", httpResp, err))
 		return
 	}
 
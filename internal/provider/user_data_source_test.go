@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccUserDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_user.test", "id", "1"),
+					resource.TestCheckResourceAttr("data.loriot_user.test", "email", "user@example.com"),
+					resource.TestCheckResourceAttr("data.loriot_user.test", "devices_limit", "1000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccUserDataSourceConfig = `
+data "loriot_user" "test" {}
+`
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"bitbucket.org/msabbott/loriot-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,7 +22,7 @@ func NewUserUsageDataSource() datasource.DataSource {
 
 // UserUsageDataSource defines the data source implementation.
 type UserUsageDataSource struct {
-	client *loriot.APIClient
+	client *loriotClient
 }
 
 // UserUsageDataSourceModel describes the data source data model.
@@ -90,12 +89,12 @@ func (d *UserUsageDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*loriot.APIClient)
+	client, ok := req.ProviderData.(*loriotClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *loriot.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *loriotClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -116,9 +115,9 @@ func (d *UserUsageDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	userusage, _, err := d.client.UserApi.V1NwkUserUsageGet(ctx)
+	userusage, httpResp, err := d.client.UserApi.V1NwkUserUsageGet(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read User, got error: %s", err))
+		resp.Diagnostics.AddError(clientError("Unable to read User", httpResp, err))
 		return
 	}
 
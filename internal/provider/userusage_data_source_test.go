@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserUsageDataSource(t *testing.T) {
+	server := newMockLoriotServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: server.providerConfig() + testAccUserUsageDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.loriot_userusage.test", "apps", "1"),
+					resource.TestCheckResourceAttr("data.loriot_userusage.test", "devices_used", "10"),
+					resource.TestCheckResourceAttr("data.loriot_userusage.test", "devices_limit", "1000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccUserUsageDataSourceConfig = `
+resource "loriot_app" "test" {
+  name                = "test"
+  devices_limit       = 10
+  mcast_devices_limit = 0
+}
+
+data "loriot_userusage" "test" {
+  depends_on = [loriot_app.test]
+}
+`
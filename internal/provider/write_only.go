@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeOnlyValue returns the value of the write-only variant of an attribute
+// when it is configured, or value otherwise. Write-only values are never in the
+// plan or state, so writeOnly must be read from the configuration.
+func writeOnlyValue(value types.String, writeOnly types.String) types.String {
+	if !writeOnly.IsNull() {
+		return writeOnly
+	}
+
+	return value
+}